@@ -0,0 +1,47 @@
+package config
+
+import (
+	"io"
+
+	"github.com/fxamacker/cbor/v2"
+
+	flam "github.com/happyhippyhippo/flam"
+)
+
+type cborParser struct {
+	decoder cbor.DecMode
+}
+
+func newCborParser() (Parser, error) {
+	decoder, e := cbor.DecOptions{
+		MapKeyByteString: cbor.MapKeyByteStringAllowed,
+		TimeTagToAny:     cbor.TimeTagToTime,
+	}.DecMode()
+	if e != nil {
+		return nil, e
+	}
+
+	return &cborParser{
+		decoder: decoder,
+	}, nil
+}
+
+func (parser cborParser) Close() error {
+	return nil
+}
+
+func (parser cborParser) Parse(
+	reader io.Reader,
+) (flam.Bag, error) {
+	b, e := io.ReadAll(reader)
+	if e != nil {
+		return nil, e
+	}
+
+	data := map[any]any{}
+	if e := parser.decoder.Unmarshal(b, &data); e != nil {
+		return nil, e
+	}
+
+	return Convert(data).(flam.Bag), nil
+}
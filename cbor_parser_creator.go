@@ -0,0 +1,23 @@
+package config
+
+import (
+	flam "github.com/happyhippyhippo/flam"
+)
+
+type cborParserCreator struct{}
+
+func newCborParserCreator() ParserCreator {
+	return &cborParserCreator{}
+}
+
+func (cborParserCreator) Accept(
+	config flam.Bag,
+) bool {
+	return config.String("driver") == ParserDriverCbor
+}
+
+func (cborParserCreator) Create(
+	_ flam.Bag,
+) (Parser, error) {
+	return newCborParser()
+}
@@ -6,6 +6,25 @@ const (
 	ParserCreatorGroup         = "flam.config.parsers.creator"
 	ParserDriverYaml           = "flam.config.parsers.driver.yaml"
 	ParserDriverJson           = "flam.config.parsers.driver.json"
+	ParserDriverToml           = "flam.config.parsers.driver.toml"
+	ParserDriverIni            = "flam.config.parsers.driver.ini"
+	ParserDriverProperties     = "flam.config.parsers.driver.properties"
+	ParserDriverHcl            = "flam.config.parsers.driver.hcl"
+	ParserDriverXml            = "flam.config.parsers.driver.xml"
+	ParserDriverJson5          = "flam.config.parsers.driver.json5"
+	ParserDriverHocon          = "flam.config.parsers.driver.hocon"
+	ParserDriverJsonnet        = "flam.config.parsers.driver.jsonnet"
+	ParserDriverCue            = "flam.config.parsers.driver.cue"
+	ParserDriverStarlark       = "flam.config.parsers.driver.starlark"
+	ParserDriverMessagePack    = "flam.config.parsers.driver.msgpack"
+	ParserDriverCbor           = "flam.config.parsers.driver.cbor"
+	ParserDriverDotenv         = "flam.config.parsers.driver.dotenv"
+	ParserDriverSops           = "flam.config.parsers.driver.sops"
+	YamlTagResolverGroup       = "flam.config.parsers.yaml.tag.resolver"
+	YamlTagEnv                 = "!env"
+	YamlTagFile                = "!file"
+	YamlTagInclude             = "!include"
+	YamlTagBase64              = "!base64"
 	SourceCreatorGroup         = "flam.config.sources.creator"
 	SourceDriverEnv            = "flam.config.sources.driver.env"
 	SourceDriverFile           = "flam.config.sources.driver.file"
@@ -13,12 +32,30 @@ const (
 	SourceDriverDir            = "flam.config.sources.driver.dir"
 	SourceDriverRest           = "flam.config.sources.driver.rest"
 	SourceDriverObservableRest = "flam.config.sources.driver.observable-rest"
+	SourceDriverFlags          = "flam.config.sources.driver.flags"
+	SourceDriverObservableEnv  = "flam.config.sources.driver.observable-env"
 
-	PathDefaultFileParser = "flam.config.defaults.file.parser"
-	PathDefaultFileDisk   = "flam.config.defaults.file.disk"
-	PathDefaultRestParser = "flam.config.defaults.rest.parser"
-	PathBoot              = "flam.config.boot"
-	PathObserverFrequency = "flam.config.observer.frequency"
-	PathParsers           = "flam.config.parsers"
-	PathSources           = "flam.config.sources"
+	EnvCaseLower    = "lower"
+	EnvCaseUpper    = "upper"
+	EnvCasePreserve = "preserve"
+
+	EnvTypeString = "string"
+	EnvTypeInt    = "int"
+	EnvTypeFloat  = "float"
+	EnvTypeBool   = "bool"
+	EnvTypeList   = "list"
+	EnvTypeJson   = "json"
+
+	PathDefaultFileParser          = "flam.config.defaults.file.parser"
+	PathDefaultFileDisk            = "flam.config.defaults.file.disk"
+	PathDefaultRestParser          = "flam.config.defaults.rest.parser"
+	PathDefaultMaxDecompressedSize = "flam.config.defaults.max_decompressed_size"
+	PathParserExtensions           = "flam.config.defaults.parser.extensions"
+	PathParserContentTypes         = "flam.config.defaults.parser.content_types"
+	PathSecretsDisk                = "flam.config.secrets.disk"
+	PathSecretsKeyring             = "flam.config.secrets.keyring"
+	PathBoot                       = "flam.config.boot"
+	PathObserverFrequency          = "flam.config.observer.frequency"
+	PathParsers                    = "flam.config.parsers"
+	PathSources                    = "flam.config.sources"
 )
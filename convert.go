@@ -2,6 +2,8 @@ package config
 
 import (
 	"fmt"
+	"math"
+	"reflect"
 	"strings"
 
 	flam "github.com/happyhippyhippo/flam"
@@ -28,6 +30,15 @@ func Convert(
 		return result
 	}
 
+	if lValue, ok := val.([]map[string]any); ok {
+		var result []any
+		for _, i := range lValue {
+			result = append(result, Convert(i))
+		}
+
+		return result
+	}
+
 	if mValue, ok := val.(map[string]any); ok {
 		result := flam.Bag{}
 		for k, i := range mValue {
@@ -40,20 +51,57 @@ func Convert(
 	if mValue, ok := val.(map[any]any); ok {
 		result := flam.Bag{}
 		for k, i := range mValue {
-			stringKey, ok := k.(string)
-			if ok {
-				result[strings.ToLower(stringKey)] = Convert(i)
-			} else {
-				result[fmt.Sprintf("%v", k)] = Convert(i)
-			}
+			result[convertKey(k)] = Convert(i)
 		}
 
 		return result
 	}
 
+	switch nValue := val.(type) {
+	case int8:
+		return int(nValue)
+	case int16:
+		return int(nValue)
+	case int32:
+		return int(nValue)
+	case uint8:
+		return int(nValue)
+	case uint16:
+		return int(nValue)
+	case uint32:
+		return int(nValue)
+	case float32:
+		val = float64(nValue)
+	}
+
 	if fValue, ok := val.(float64); ok && float64(int(fValue)) == fValue {
 		return int(fValue)
 	}
 
+	if iValue, ok := val.(int64); ok && int64(int(iValue)) == iValue {
+		return int(iValue)
+	}
+
+	if uValue, ok := val.(uint64); ok && uValue <= math.MaxInt {
+		return int(uValue)
+	}
+
 	return val
 }
+
+func convertKey(
+	key any,
+) string {
+	switch k := key.(type) {
+	case string:
+		return strings.ToLower(k)
+	case []byte:
+		return strings.ToLower(string(k))
+	}
+
+	if v := reflect.ValueOf(key); v.Kind() == reflect.String {
+		return strings.ToLower(v.String())
+	}
+
+	return fmt.Sprintf("%v", key)
+}
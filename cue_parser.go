@@ -0,0 +1,211 @@
+package config
+
+import (
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"cuelang.org/go/cue"
+	cueBuild "cuelang.org/go/cue/build"
+	"cuelang.org/go/cue/cuecontext"
+	cueErrors "cuelang.org/go/cue/errors"
+	cueSyntax "cuelang.org/go/cue/parser"
+
+	flam "github.com/happyhippyhippo/flam"
+	filesystem "github.com/happyhippyhippo/flam-filesystem"
+)
+
+type cueParser struct {
+	schemaDisk filesystem.Disk
+	schema     string
+}
+
+func newCueParser(
+	schemaDisk filesystem.Disk,
+	schema string,
+) Parser {
+	return &cueParser{
+		schemaDisk: schemaDisk,
+		schema:     schema,
+	}
+}
+
+func (parser *cueParser) Close() error {
+	return nil
+}
+
+func (parser *cueParser) Parse(
+	reader io.Reader,
+) (flam.Bag, error) {
+	return parser.ParseFrom(nil, "", reader)
+}
+
+func (parser *cueParser) ParseFrom(
+	disk filesystem.Disk,
+	filePath string,
+	reader io.Reader,
+) (flam.Bag, error) {
+	b, e := io.ReadAll(reader)
+	if e != nil {
+		return nil, e
+	}
+
+	ctx := cuecontext.New()
+
+	var value cue.Value
+	if pkg := parser.packageName(filePath, b); disk != nil && filePath != "" && pkg != "" {
+		value, e = parser.compilePackage(ctx, disk, filePath, pkg, b)
+	} else {
+		value, e = parser.compile(ctx, filePath, b)
+	}
+	if e != nil {
+		return nil, e
+	}
+
+	if parser.schema != "" {
+		schemaDisk := parser.schemaDisk
+		if schemaDisk == nil {
+			schemaDisk = disk
+		}
+		if schemaDisk == nil {
+			return nil, newErrCueSchemaDiskNotFound(parser.schema)
+		}
+
+		schema, e := parser.compileFile(ctx, schemaDisk, parser.schema)
+		if e != nil {
+			return nil, e
+		}
+		value = schema.Unify(value)
+	}
+
+	if e := value.Validate(cue.Concrete(true), cue.Final()); e != nil {
+		return nil, newErrCueEvaluation(cueErrors.Details(e, nil))
+	}
+
+	data := map[string]any{}
+	if e := value.Decode(&data); e != nil {
+		return nil, newErrCueEvaluation(cueErrors.Details(e, nil))
+	}
+
+	return Convert(data).(flam.Bag), nil
+}
+
+func (parser *cueParser) compile(
+	ctx *cue.Context,
+	filePath string,
+	b []byte,
+) (cue.Value, error) {
+	value := ctx.CompileBytes(b, cue.Filename(filePath))
+	if e := value.Err(); e != nil {
+		return cue.Value{}, newErrCueEvaluation(cueErrors.Details(e, nil))
+	}
+
+	return value, nil
+}
+
+func (parser *cueParser) compileFile(
+	ctx *cue.Context,
+	disk filesystem.Disk,
+	filePath string,
+) (cue.Value, error) {
+	b, e := parser.readFile(disk, filePath)
+	if e != nil {
+		return cue.Value{}, e
+	}
+
+	return parser.compile(ctx, filePath, b)
+}
+
+func (parser *cueParser) compilePackage(
+	ctx *cue.Context,
+	disk filesystem.Disk,
+	filePath string,
+	pkg string,
+	b []byte,
+) (cue.Value, error) {
+	instance := cueBuild.NewContext().NewInstance(path.Dir(filePath), nil)
+	if e := parser.addFile(instance, filePath, b); e != nil {
+		return cue.Value{}, e
+	}
+
+	dir, e := disk.Open(path.Dir(filePath))
+	if e != nil {
+		return cue.Value{}, e
+	}
+	names, e := dir.Readdirnames(0)
+	_ = dir.Close()
+	if e != nil {
+		return cue.Value{}, e
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		sibling := path.Join(path.Dir(filePath), name)
+		if !strings.HasSuffix(name, ".cue") || sibling == path.Clean(filePath) {
+			continue
+		}
+
+		sb, e := parser.readFile(disk, sibling)
+		if e != nil {
+			return cue.Value{}, e
+		}
+		if parser.packageName(sibling, sb) != pkg {
+			continue
+		}
+
+		if e := parser.addFile(instance, sibling, sb); e != nil {
+			return cue.Value{}, e
+		}
+	}
+
+	value := ctx.BuildInstance(instance)
+	if e := value.Err(); e != nil {
+		return cue.Value{}, newErrCueEvaluation(cueErrors.Details(e, nil))
+	}
+
+	return value, nil
+}
+
+func (parser *cueParser) addFile(
+	instance *cueBuild.Instance,
+	filePath string,
+	b []byte,
+) error {
+	file, e := cueSyntax.ParseFile(filePath, b)
+	if e != nil {
+		return newErrCueEvaluation(cueErrors.Details(e, nil))
+	}
+
+	if e := instance.AddSyntax(file); e != nil {
+		return newErrCueEvaluation(cueErrors.Details(e, nil))
+	}
+
+	return nil
+}
+
+func (parser *cueParser) packageName(
+	filePath string,
+	b []byte,
+) string {
+	file, e := cueSyntax.ParseFile(filePath, b, cueSyntax.PackageClauseOnly)
+	if e != nil {
+		return ""
+	}
+
+	return file.PackageName()
+}
+
+func (parser *cueParser) readFile(
+	disk filesystem.Disk,
+	filePath string,
+) ([]byte, error) {
+	file, e := disk.OpenFile(filePath, os.O_RDONLY, 0o644)
+	if e != nil {
+		return nil, e
+	}
+	defer func() { _ = file.Close() }()
+
+	return io.ReadAll(file)
+}
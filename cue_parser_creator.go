@@ -0,0 +1,52 @@
+package config
+
+import (
+	"go.uber.org/dig"
+
+	flam "github.com/happyhippyhippo/flam"
+	filesystem "github.com/happyhippyhippo/flam-filesystem"
+)
+
+type cueParserCreator struct {
+	fileSystemFacade filesystem.Facade
+}
+
+type cueParserCreatorArgs struct {
+	dig.In
+
+	FileSystemFacade filesystem.Facade `optional:"true"`
+}
+
+func newCueParserCreator(
+	args cueParserCreatorArgs,
+) ParserCreator {
+	return &cueParserCreator{
+		fileSystemFacade: args.FileSystemFacade,
+	}
+}
+
+func (cueParserCreator) Accept(
+	config flam.Bag,
+) bool {
+	return config.String("driver") == ParserDriverCue
+}
+
+func (creator cueParserCreator) Create(
+	config flam.Bag,
+) (Parser, error) {
+	var disk filesystem.Disk
+	if diskId := config.String("disk"); diskId != "" {
+		if creator.fileSystemFacade == nil {
+			return nil, newErrCueSchemaDiskNotFound(diskId)
+		}
+
+		var e error
+		if disk, e = creator.fileSystemFacade.GetDisk(diskId); e != nil {
+			return nil, e
+		}
+	}
+
+	return newCueParser(
+		disk,
+		config.String("schema")), nil
+}
@@ -0,0 +1,157 @@
+package config
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	compressionNone = ""
+	compressionGzip = "gzip"
+	compressionZstd = "zstd"
+)
+
+var (
+	compressionExtensions = map[string]string{
+		".gz":   compressionGzip,
+		".gzip": compressionGzip,
+		".zst":  compressionZstd,
+		".zstd": compressionZstd,
+	}
+	compressionEncodings = map[string]string{
+		"":         compressionNone,
+		"identity": compressionNone,
+		"gzip":     compressionGzip,
+		"x-gzip":   compressionGzip,
+		"zstd":     compressionZstd,
+	}
+	compressionGzipMagic = []byte{0x1f, 0x8b}
+	compressionZstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+func trimCompressionExtension(
+	filePath string,
+) string {
+	if _, ok := compressionExtensions[strings.ToLower(path.Ext(filePath))]; ok {
+		return strings.TrimSuffix(filePath, path.Ext(filePath))
+	}
+
+	return filePath
+}
+
+func decompressFile(
+	reader io.Reader,
+	filePath string,
+	maxSize int,
+) (io.ReadCloser, error) {
+	return decompress(reader, compressionExtensions[strings.ToLower(path.Ext(filePath))], maxSize)
+}
+
+func decompressResponse(
+	reader io.Reader,
+	encoding string,
+	maxSize int,
+) (io.ReadCloser, error) {
+	compression, ok := compressionEncodings[strings.ToLower(strings.TrimSpace(encoding))]
+	if !ok {
+		return nil, newErrUnsupportedContentEncoding(encoding)
+	}
+
+	return decompress(reader, compression, maxSize)
+}
+
+func decompress(
+	reader io.Reader,
+	compression string,
+	maxSize int,
+) (io.ReadCloser, error) {
+	if compression == compressionNone {
+		content, e := io.ReadAll(reader)
+		if e != nil {
+			return nil, e
+		}
+
+		switch {
+		case bytes.HasPrefix(content, compressionGzipMagic):
+			compression = compressionGzip
+		case bytes.HasPrefix(content, compressionZstdMagic):
+			compression = compressionZstd
+		}
+		reader = bytes.NewReader(content)
+	}
+
+	switch compression {
+	case compressionGzip:
+		decompressor, e := gzip.NewReader(reader)
+		if e != nil {
+			return nil, e
+		}
+		return newDecompressReader(decompressor, decompressor.Close, maxSize), nil
+	case compressionZstd:
+		decompressor, e := zstd.NewReader(reader, zstd.WithDecoderConcurrency(1))
+		if e != nil {
+			return nil, e
+		}
+		return newDecompressReader(decompressor, func() error {
+			decompressor.Close()
+			return nil
+		}, maxSize), nil
+	}
+
+	return io.NopCloser(reader), nil
+}
+
+type decompressReader struct {
+	reader    io.Reader
+	closer    func() error
+	maxSize   int
+	remaining int64
+}
+
+func newDecompressReader(
+	reader io.Reader,
+	closer func() error,
+	maxSize int,
+) io.ReadCloser {
+	return &decompressReader{
+		reader:    reader,
+		closer:    closer,
+		maxSize:   maxSize,
+		remaining: int64(maxSize),
+	}
+}
+
+func (reader *decompressReader) Read(
+	p []byte,
+) (int, error) {
+	if reader.maxSize <= 0 {
+		return reader.reader.Read(p)
+	}
+
+	if reader.remaining <= 0 {
+		var probe [1]byte
+		n, e := reader.reader.Read(probe[:])
+		if n > 0 {
+			return 0, newErrDecompressedSizeExceeded(reader.maxSize)
+		}
+		return 0, e
+	}
+
+	if int64(len(p)) > reader.remaining {
+		p = p[:reader.remaining]
+	}
+
+	n, e := reader.reader.Read(p)
+	reader.remaining -= int64(n)
+
+	return n, e
+}
+
+func (reader *decompressReader) Close() error {
+	return reader.closer()
+}
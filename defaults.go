@@ -10,4 +10,60 @@ var (
 	DefaultFileParser = ""
 	DefaultFileDisk   = ""
 	DefaultRestParser = ""
+
+	DefaultEnvSeparator     = "__"
+	DefaultEnvCase          = EnvCaseLower
+	DefaultEnvListSeparator = ","
+
+	DefaultMaxDecompressedSize = 64 * 1024 * 1024
+
+	DefaultParserExtensions = map[string]string{
+		"yaml":       "yaml",
+		"yml":        "yaml",
+		"json":       "json",
+		"toml":       "toml",
+		"ini":        "ini",
+		"properties": "properties",
+		"hcl":        "hcl",
+		"xml":        "xml",
+		"json5":      "json5",
+		"jsonc":      "json5",
+		"conf":       "hocon",
+		"hocon":      "hocon",
+		"jsonnet":    "jsonnet",
+		"libsonnet":  "jsonnet",
+		"cue":        "cue",
+		"star":       "starlark",
+		"msgpack":    "msgpack",
+		"mpk":        "msgpack",
+		"cbor":       "cbor",
+		"env":        "dotenv",
+	}
+	DefaultParserContentTypes = map[string]string{
+		"application/json":        "json",
+		"+json":                   "json",
+		"application/yaml":        "yaml",
+		"application/x-yaml":      "yaml",
+		"text/yaml":               "yaml",
+		"+yaml":                   "yaml",
+		"application/toml":        "toml",
+		"application/xml":         "xml",
+		"text/xml":                "xml",
+		"+xml":                    "xml",
+		"application/hocon":       "hocon",
+		"application/msgpack":     "msgpack",
+		"application/x-msgpack":   "msgpack",
+		"application/vnd.msgpack": "msgpack",
+		"application/cbor":        "cbor",
+		"+cbor":                   "cbor",
+	}
+
+	DefaultXmlAttributePrefix = "@"
+	DefaultXmlTextKey         = "#text"
+
+	DefaultStarlarkMaxSteps = 1000000
+
+	DefaultDotenvSeparator = "__"
+
+	DefaultSopsAgeKeyEnv = "SOPS_AGE_KEY"
 )
@@ -1,6 +1,9 @@
 package config
 
 import (
+	"bytes"
+	"errors"
+	"io"
 	"os"
 	"sync"
 
@@ -11,29 +14,40 @@ import (
 type dirSource struct {
 	source
 
-	disk      filesystem.Disk
-	path      string
-	parser    Parser
-	recursive bool
+	disk                filesystem.Disk
+	path                string
+	parser              Parser
+	parserRegistry      *parserRegistry
+	recursive           bool
+	maxDecompressedSize int
+	templateRenderer    *templateRenderer
 }
 
 func newDirSource(
+	id string,
 	priority int,
 	disk filesystem.Disk,
 	path string,
 	parser Parser,
+	parserRegistry *parserRegistry,
 	recursive bool,
+	maxDecompressedSize int,
+	templateRenderer *templateRenderer,
 ) (Source, error) {
 	source := &dirSource{
 		source: source{
+			id:       id,
 			mutex:    &sync.Mutex{},
 			bag:      flam.Bag{},
 			priority: priority,
 		},
-		disk:      disk,
-		path:      path,
-		parser:    parser,
-		recursive: recursive,
+		disk:                disk,
+		path:                path,
+		parser:              parser,
+		parserRegistry:      parserRegistry,
+		recursive:           recursive,
+		maxDecompressedSize: maxDecompressedSize,
+		templateRenderer:    templateRenderer,
 	}
 
 	if e := source.load(); e != nil {
@@ -97,11 +111,42 @@ func (source *dirSource) loadDir(
 func (source *dirSource) loadFile(
 	path string,
 ) (flam.Bag, error) {
+	parser := source.parser
+	if parser == nil {
+		selected, e := source.parserRegistry.ForPath(path)
+		switch {
+		case errors.Is(e, ErrParserNotFoundForExtension):
+			return flam.Bag{}, nil
+		case e != nil:
+			return nil, e
+		}
+		parser = selected
+	}
+
 	file, e := source.disk.OpenFile(path, os.O_RDONLY, 0o644)
 	if e != nil {
 		return nil, e
 	}
 	defer func() { _ = file.Close() }()
 
-	return source.parser.Parse(file)
+	reader, e := decompressFile(file, path, source.maxDecompressedSize)
+	if e != nil {
+		return nil, e
+	}
+	defer func() { _ = reader.Close() }()
+
+	var rendered io.Reader = reader
+	if source.templateRenderer != nil {
+		if rendered, e = source.templateRenderer.Render(source.id, path, reader); e != nil {
+			return nil, e
+		}
+	}
+
+	content := &bytes.Buffer{}
+	bag, e := parseFrom(parser, source.disk, path, io.TeeReader(rendered, content))
+	if e != nil {
+		return nil, newParseError(source.id, path, content.Bytes(), e)
+	}
+
+	return bag, nil
 }
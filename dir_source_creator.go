@@ -8,15 +8,21 @@ import (
 type dirSourceCreator struct {
 	fileSystemFacade filesystem.Facade
 	parserFactory    parserFactory
+	parserRegistry   *parserRegistry
+	templateRenderer *templateRenderer
 }
 
 func newDirSourceCreator(
 	fileSystemFacade filesystem.Facade,
 	parserFactory parserFactory,
+	parserRegistry *parserRegistry,
+	templateRenderer *templateRenderer,
 ) SourceCreator {
 	return &dirSourceCreator{
 		fileSystemFacade: fileSystemFacade,
 		parserFactory:    parserFactory,
+		parserRegistry:   parserRegistry,
+		templateRenderer: templateRenderer,
 	}
 }
 
@@ -36,16 +42,21 @@ func (creator dirSourceCreator) Create(
 		return nil, e
 	}
 
-	parserId := config.String("parser", DefaultFileParser)
-	parser, e := creator.parserFactory.Get(parserId)
-	if e != nil {
-		return nil, e
+	var parser Parser
+	if parserId := config.String("parser"); parserId != "" {
+		if parser, e = creator.parserFactory.Get(parserId); e != nil {
+			return nil, e
+		}
 	}
 
 	return newDirSource(
+		config.String("id"),
 		config.Int("priority"),
 		disk,
 		config.String("path"),
 		parser,
-		config.Bool("recursive"))
+		creator.parserRegistry,
+		config.Bool("recursive"),
+		config.Int("max_decompressed_size", DefaultMaxDecompressedSize),
+		creator.templateRenderer.forSource(config))
 }
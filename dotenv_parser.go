@@ -0,0 +1,62 @@
+package config
+
+import (
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/joho/godotenv"
+
+	flam "github.com/happyhippyhippo/flam"
+)
+
+type dotenvParser struct {
+	separator string
+}
+
+func newDotenvParser(
+	separator string,
+) Parser {
+	return &dotenvParser{
+		separator: separator,
+	}
+}
+
+func (parser dotenvParser) Close() error {
+	return nil
+}
+
+func (parser dotenvParser) Parse(
+	reader io.Reader,
+) (flam.Bag, error) {
+	values, e := godotenv.Parse(reader)
+	if e != nil {
+		return nil, e
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	data := flam.Bag{}
+	for _, key := range keys {
+		if e := data.Set(parser.path(key), values[key]); e != nil {
+			return nil, e
+		}
+	}
+
+	return data, nil
+}
+
+func (parser dotenvParser) path(
+	key string,
+) string {
+	key = strings.ToLower(key)
+	if parser.separator == "" {
+		return key
+	}
+
+	return strings.Join(strings.Split(key, strings.ToLower(parser.separator)), ".")
+}
@@ -0,0 +1,24 @@
+package config
+
+import (
+	flam "github.com/happyhippyhippo/flam"
+)
+
+type dotenvParserCreator struct{}
+
+func newDotenvParserCreator() ParserCreator {
+	return &dotenvParserCreator{}
+}
+
+func (dotenvParserCreator) Accept(
+	config flam.Bag,
+) bool {
+	return config.String("driver") == ParserDriverDotenv
+}
+
+func (dotenvParserCreator) Create(
+	config flam.Bag,
+) (Parser, error) {
+	return newDotenvParser(
+		config.String("separator", DefaultDotenvSeparator)), nil
+}
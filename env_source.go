@@ -1,35 +1,75 @@
 package config
 
 import (
+	"encoding/json"
+	"io"
+	"math"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 
 	"github.com/joho/godotenv"
 
 	flam "github.com/happyhippyhippo/flam"
+	filesystem "github.com/happyhippyhippo/flam-filesystem"
 )
 
+type envMapping struct {
+	path string
+	kind string
+	sep  string
+}
+
 type envSource struct {
 	source
 
-	files    []string
-	mappings map[string]string
+	files            []string
+	mappings         map[string]envMapping
+	prefix           string
+	separator        string
+	caseRule         string
+	infer            bool
+	isolated         bool
+	prefixFiles      bool
+	fileSystemFacade filesystem.Facade
+	diskId           string
 }
 
 func newEnvSource(
 	priority int,
 	files []string,
-	mappings map[string]string,
+	mappings map[string]envMapping,
+	prefix string,
+	separator string,
+	caseRule string,
+	infer bool,
+	isolated bool,
+	prefixFiles bool,
+	fileSystemFacade filesystem.Facade,
+	diskId string,
 ) (Source, error) {
+	if e := checkEnvSourceOptions(caseRule, mappings); e != nil {
+		return nil, e
+	}
+
 	source := &envSource{
 		source: source{
 			mutex:    &sync.Mutex{},
 			bag:      flam.Bag{},
 			priority: priority,
 		},
-		files:    files,
-		mappings: mappings,
+		files:            files,
+		mappings:         mappings,
+		prefix:           prefix,
+		separator:        separator,
+		caseRule:         caseRule,
+		infer:            infer,
+		isolated:         isolated,
+		prefixFiles:      prefixFiles,
+		fileSystemFacade: fileSystemFacade,
+		diskId:           diskId,
 	}
 
 	if e := source.load(); e != nil {
@@ -39,33 +79,264 @@ func newEnvSource(
 	return source, nil
 }
 
+func checkEnvSourceOptions(
+	caseRule string,
+	mappings map[string]envMapping,
+) error {
+	switch caseRule {
+	case EnvCaseLower, EnvCaseUpper, EnvCasePreserve:
+	default:
+		return newErrEnvInvalidCase(caseRule)
+	}
+
+	for key, mapping := range mappings {
+		switch mapping.kind {
+		case "", EnvTypeString, EnvTypeInt, EnvTypeFloat, EnvTypeBool, EnvTypeList, EnvTypeJson:
+		default:
+			return newErrEnvInvalidType(key, mapping.kind)
+		}
+	}
+
+	return nil
+}
+
 func (source *envSource) load() error {
-	if len(source.files) != 0 {
-		if e := godotenv.Load(source.files...); e != nil {
+	env, e := source.environ()
+	if e != nil {
+		return e
+	}
+
+	bag := flam.Bag{}
+	if source.prefix != "" {
+		if e := source.loadPrefixed(env, bag); e != nil {
 			return e
 		}
 	}
 
-	for key, path := range source.mappings {
-		env := os.Getenv(key)
-		if env == "" {
+	for key, mapping := range source.mappings {
+		value := env[key]
+		if filePath := env[key+"_FILE"]; value == "" && filePath != "" {
+			if value, e = source.readFile(filePath); e != nil {
+				return e
+			}
+		}
+		if value == "" {
 			continue
 		}
 
-		step := source.bag
-		sections := strings.Split(path, ".")
-		for i, section := range sections {
-			if i != len(sections)-1 {
-				if _, ok := step[section]; !ok {
-					step[section] = flam.Bag{}
-				}
+		coerced, e := source.coerce(key, value, mapping)
+		if e != nil {
+			return e
+		}
+
+		if e := bag.Set(mapping.path, coerced); e != nil {
+			return e
+		}
+	}
+
+	source.mutex.Lock()
+	source.bag = bag
+	source.mutex.Unlock()
 
-				step = step[section].(flam.Bag)
-			} else {
-				step[section] = env
+	return nil
+}
+
+func (source *envSource) environ() (map[string]string, error) {
+	env := map[string]string{}
+	if len(source.files) != 0 {
+		if source.isolated {
+			read, e := godotenv.Read(source.files...)
+			if e != nil {
+				return nil, e
 			}
+			env = read
+		} else if e := godotenv.Load(source.files...); e != nil {
+			return nil, e
+		}
+	}
+
+	for _, entry := range os.Environ() {
+		if key, value, ok := strings.Cut(entry, "="); ok {
+			env[key] = value
+		}
+	}
+
+	return env, nil
+}
+
+func (source *envSource) readFile(
+	filePath string,
+) (string, error) {
+	if source.fileSystemFacade == nil {
+		return "", newErrEnvFileDiskNotFound(filePath)
+	}
+
+	disk, e := source.fileSystemFacade.GetDisk(source.diskId)
+	if e != nil {
+		return "", e
+	}
+
+	file, e := disk.OpenFile(filePath, os.O_RDONLY, 0o644)
+	if e != nil {
+		return "", e
+	}
+	defer func() { _ = file.Close() }()
+
+	b, e := io.ReadAll(file)
+	if e != nil {
+		return "", e
+	}
+
+	return strings.TrimSuffix(strings.TrimSuffix(string(b), "\n"), "\r"), nil
+}
+
+func (source *envSource) loadPrefixed(
+	env map[string]string,
+	bag flam.Bag,
+) error {
+	var keys []string
+	for key := range env {
+		if !strings.HasPrefix(key, source.prefix) {
+			continue
+		}
+		if _, ok := source.mappings[key]; ok {
+			continue
+		}
+
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		raw := env[key]
+		name := strings.TrimPrefix(key, source.prefix)
+		if base, ok := strings.CutSuffix(key, "_FILE"); ok && source.prefixFiles {
+			if _, ok := source.mappings[base]; ok || env[base] != "" {
+				continue
+			}
+
+			var e error
+			if raw, e = source.readFile(raw); e != nil {
+				return e
+			}
+			if raw == "" {
+				continue
+			}
+			name = strings.TrimPrefix(base, source.prefix)
+		}
+
+		path, ok := source.path(name)
+		if !ok {
+			continue
+		}
+
+		value, e := source.coerce(key, raw, envMapping{})
+		if e != nil {
+			return e
+		}
+
+		if e := bag.Set(path, value); e != nil {
+			return e
 		}
 	}
 
 	return nil
 }
+
+func (source *envSource) path(
+	name string,
+) (string, bool) {
+	sections := strings.Split(name, source.separator)
+	for i, section := range sections {
+		if section == "" {
+			return "", false
+		}
+
+		switch source.caseRule {
+		case EnvCaseLower:
+			sections[i] = strings.ToLower(section)
+		case EnvCaseUpper:
+			sections[i] = strings.ToUpper(section)
+		}
+	}
+
+	return strings.Join(sections, "."), true
+}
+
+func (source *envSource) coerce(
+	key string,
+	value string,
+	mapping envMapping,
+) (any, error) {
+	switch mapping.kind {
+	case "":
+		if source.infer {
+			return source.inferValue(value), nil
+		}
+		return value, nil
+	case EnvTypeString:
+		return value, nil
+	case EnvTypeInt:
+		if i, e := strconv.Atoi(strings.TrimSpace(value)); e == nil {
+			return i, nil
+		}
+	case EnvTypeFloat:
+		if f, e := strconv.ParseFloat(strings.TrimSpace(value), 64); e == nil {
+			return f, nil
+		}
+	case EnvTypeBool:
+		if b, e := strconv.ParseBool(strings.TrimSpace(value)); e == nil {
+			return b, nil
+		}
+	case EnvTypeList:
+		sep := mapping.sep
+		if sep == "" {
+			sep = DefaultEnvListSeparator
+		}
+
+		list := []any{}
+		for _, item := range strings.Split(value, sep) {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		return list, nil
+	case EnvTypeJson:
+		var decoded any
+		if e := json.Unmarshal([]byte(value), &decoded); e == nil {
+			return Convert(decoded), nil
+		}
+	}
+
+	return nil, newErrEnvInvalidValue(key, mapping.kind)
+}
+
+func (source *envSource) inferValue(
+	value string,
+) any {
+	trimmed := strings.TrimSpace(value)
+
+	switch strings.ToLower(trimmed) {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+
+	if i, e := strconv.Atoi(trimmed); e == nil {
+		return i
+	}
+
+	if f, e := strconv.ParseFloat(trimmed, 64); e == nil && !math.IsInf(f, 0) && !math.IsNaN(f) {
+		return f
+	}
+
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		var decoded any
+		if e := json.Unmarshal([]byte(trimmed), &decoded); e == nil {
+			return Convert(decoded)
+		}
+	}
+
+	return value
+}
@@ -1,13 +1,28 @@
 package config
 
 import (
+	"go.uber.org/dig"
+
 	flam "github.com/happyhippyhippo/flam"
+	filesystem "github.com/happyhippyhippo/flam-filesystem"
 )
 
-type envSourceCreator struct{}
+type envSourceCreator struct {
+	fileSystemFacade filesystem.Facade
+}
+
+type envSourceCreatorArgs struct {
+	dig.In
+
+	FileSystemFacade filesystem.Facade `optional:"true"`
+}
 
-func newEnvSourceCreator() SourceCreator {
-	return &envSourceCreator{}
+func newEnvSourceCreator(
+	args envSourceCreatorArgs,
+) SourceCreator {
+	return &envSourceCreator{
+		fileSystemFacade: args.FileSystemFacade,
+	}
 }
 
 func (envSourceCreator) Accept(
@@ -16,19 +31,42 @@ func (envSourceCreator) Accept(
 	return config.String("driver") == SourceDriverEnv
 }
 
-func (envSourceCreator) Create(
+func (creator envSourceCreator) Create(
 	config flam.Bag,
 ) (Source, error) {
-	mappings := map[string]string{}
-	for key, path := range config.Bag("mappings") {
-		if str, ok := path.(string); ok {
-			mappings[key] = str
-		}
-	}
-
 	return newEnvSource(
 		config.Int("priority"),
 		config.StringSlice("files", []string{}),
-		mappings,
+		creator.mappings(config),
+		config.String("prefix"),
+		config.String("separator", DefaultEnvSeparator),
+		config.String("case", DefaultEnvCase),
+		config.Bool("infer"),
+		config.Bool("isolated"),
+		config.Bool("prefix_files"),
+		creator.fileSystemFacade,
+		config.String("disk", DefaultFileDisk),
 	)
 }
+
+func (envSourceCreator) mappings(
+	config flam.Bag,
+) map[string]envMapping {
+	mappings := map[string]envMapping{}
+	for key, value := range config.Bag("mappings") {
+		switch mapping := value.(type) {
+		case string:
+			mappings[key] = envMapping{path: mapping}
+		case flam.Bag:
+			if mapping.Has("path") {
+				mappings[key] = envMapping{
+					path: mapping.String("path"),
+					kind: mapping.String("type"),
+					sep:  mapping.String("sep"),
+				}
+			}
+		}
+	}
+
+	return mappings
+}
@@ -4,17 +4,73 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/hashicorp/hcl/v2"
+
 	flam "github.com/happyhippyhippo/flam"
 )
 
 var (
-	ErrRestConfigNotFound    = errors.New("rest config data not found")
-	ErrRestInvalidConfig     = errors.New("invalid rest config data")
-	ErrRestTimestampNotFound = errors.New("rest config timestamp not found")
-	ErrRestInvalidTimestamp  = errors.New("invalid rest config timestamp")
-	ErrSourceNotFound        = errors.New("config source not found")
-	ErrDuplicateSource       = errors.New("duplicate config source")
-	ErrDuplicateObserver     = errors.New("duplicate config observer")
+	ErrRestConfigNotFound           = errors.New("rest config data not found")
+	ErrRestInvalidConfig            = errors.New("invalid rest config data")
+	ErrRestTimestampNotFound        = errors.New("rest config timestamp not found")
+	ErrRestInvalidTimestamp         = errors.New("invalid rest config timestamp")
+	ErrSourceNotFound               = errors.New("config source not found")
+	ErrDuplicateSource              = errors.New("duplicate config source")
+	ErrDuplicateObserver            = errors.New("duplicate config observer")
+	ErrIniInvalidLine               = errors.New("invalid ini line")
+	ErrPropertiesInvalidLine        = errors.New("invalid properties line")
+	ErrHclInvalidExpression         = errors.New("invalid hcl expression")
+	ErrHclBlockConflict             = errors.New("hcl block conflicts with an existing value")
+	ErrJson5InvalidToken            = errors.New("invalid json5 token")
+	ErrYamlTagResolution            = errors.New("unable to resolve yaml tag")
+	ErrYamlTagInvalidNode           = errors.New("invalid yaml tag node")
+	ErrYamlTagDiskNotFound          = errors.New("yaml tag disk not found")
+	ErrYamlTagEnvNotFound           = errors.New("yaml tag env variable not found")
+	ErrYamlIncludeCycle             = errors.New("yaml include cycle")
+	ErrHoconSyntax                  = errors.New("invalid hocon syntax")
+	ErrHoconSubstitutionNotFound    = errors.New("hocon substitution not found")
+	ErrHoconSubstitutionCycle       = errors.New("hocon substitution cycle")
+	ErrHoconInvalidConcatenation    = errors.New("invalid hocon value concatenation")
+	ErrHoconDiskNotFound            = errors.New("hocon include disk not found")
+	ErrHoconIncludeCycle            = errors.New("hocon include cycle")
+	ErrJsonnetDiskNotFound          = errors.New("jsonnet import disk not found")
+	ErrJsonnetImportNotFound        = errors.New("jsonnet import not found")
+	ErrCueEvaluation                = errors.New("cue evaluation error")
+	ErrCueSchemaDiskNotFound        = errors.New("cue schema disk not found")
+	ErrStarlarkExecution            = errors.New("starlark execution error")
+	ErrStarlarkDiskNotFound         = errors.New("starlark load disk not found")
+	ErrStarlarkLoadCycle            = errors.New("starlark load cycle")
+	ErrStarlarkInvalidValue         = errors.New("invalid starlark value")
+	ErrMessagePackInvalidRoot       = errors.New("invalid messagepack document root")
+	ErrParse                        = errors.New("config parse error")
+	ErrParserDocumentTooLarge       = errors.New("config document exceeds the maximum size")
+	ErrParserMaxDepthExceeded       = errors.New("config document exceeds the maximum depth")
+	ErrParserDuplicateKey           = errors.New("duplicate config key")
+	ErrParserUnknownField           = errors.New("unknown config field")
+	ErrParserNotFoundForExtension   = errors.New("no parser registered for the file extension")
+	ErrParserNotFoundForContentType = errors.New("no parser registered for the content type")
+	ErrDecompressedSizeExceeded     = errors.New("decompressed size exceeds the limit")
+	ErrUnsupportedContentEncoding   = errors.New("unsupported content encoding")
+	ErrTemplateRender               = errors.New("config template render error")
+	ErrTemplateRequiredValue        = errors.New("required template value is empty")
+	ErrSopsInvalidDocument          = errors.New("invalid sops document")
+	ErrSopsDataKeyNotFound          = errors.New("no age identity can decrypt the sops data key")
+	ErrSopsMacMismatch              = errors.New("sops mac mismatch")
+	ErrSopsInvalidValue             = errors.New("invalid sops encrypted value")
+	ErrSopsDecryption               = errors.New("sops value decryption error")
+	ErrSopsKeyDiskNotFound          = errors.New("sops key disk not found")
+	ErrSecretInvalidKey             = errors.New("invalid secret keyring key")
+	ErrSecretKeyNotFound            = errors.New("secret key not found in keyring")
+	ErrSecretInvalidValue           = errors.New("invalid encrypted secret value")
+	ErrSecretDecryption             = errors.New("secret decryption error")
+	ErrSecretKeyringDiskNotFound    = errors.New("secret keyring disk not found")
+	ErrFlagsInvalidType             = errors.New("invalid flag type")
+	ErrEnvInvalidCase               = errors.New("invalid env case rule")
+	ErrEnvInvalidType               = errors.New("invalid env mapping type")
+	ErrEnvInvalidValue              = errors.New("invalid env value for the mapping type")
+	ErrEnvFileDiskNotFound          = errors.New("env file disk not found")
+	ErrFlagsHelp                    = errors.New("flags help requested")
+	ErrParserStrictWithoutFields    = errors.New("strict parser mode requires a fields list")
 )
 
 func newErrNilReference(
@@ -84,3 +140,442 @@ func newErrDuplicateObserver(
 		ErrDuplicateObserver,
 		fmt.Sprintf("%s => %s", path, id))
 }
+
+func newErrIniInvalidLine(
+	line int,
+	content string,
+) error {
+	return newPositionError(line, 0, flam.NewErrorFrom(
+		ErrIniInvalidLine,
+		fmt.Sprintf("%d => %s", line, content)))
+}
+
+func newErrPropertiesInvalidLine(
+	line int,
+	content string,
+) error {
+	return newPositionError(line, 0, flam.NewErrorFrom(
+		ErrPropertiesInvalidLine,
+		fmt.Sprintf("%d => %s", line, content)))
+}
+
+func newErrHclInvalidExpression(
+	rng hcl.Range,
+) error {
+	return flam.NewErrorFrom(
+		ErrHclInvalidExpression,
+		rng.String())
+}
+
+func newErrHclBlockConflict(
+	rng hcl.Range,
+) error {
+	return flam.NewErrorFrom(
+		ErrHclBlockConflict,
+		rng.String())
+}
+
+func newErrJson5InvalidToken(
+	offset int,
+	token string,
+) error {
+	return flam.NewErrorFrom(
+		ErrJson5InvalidToken,
+		fmt.Sprintf("%d => %s", offset, token))
+}
+
+func newErrYamlTagResolution(
+	path string,
+	line int,
+	column int,
+	tag string,
+	e error,
+) error {
+	return flam.NewErrorFrom(
+		ErrYamlTagResolution,
+		fmt.Sprintf("%s:%d:%d %s => %v", path, line, column, tag, e))
+}
+
+func newErrYamlTagInvalidNode(
+	tag string,
+) error {
+	return flam.NewErrorFrom(
+		ErrYamlTagInvalidNode,
+		tag)
+}
+
+func newErrYamlTagDiskNotFound(
+	path string,
+) error {
+	return flam.NewErrorFrom(
+		ErrYamlTagDiskNotFound,
+		path)
+}
+
+func newErrYamlTagEnvNotFound(
+	name string,
+) error {
+	return flam.NewErrorFrom(
+		ErrYamlTagEnvNotFound,
+		name)
+}
+
+func newErrYamlIncludeCycle(
+	path string,
+) error {
+	return flam.NewErrorFrom(
+		ErrYamlIncludeCycle,
+		path)
+}
+
+func newErrHoconSyntax(
+	path string,
+	line int,
+	column int,
+	message string,
+) error {
+	return newPositionError(line, column, flam.NewErrorFrom(
+		ErrHoconSyntax,
+		fmt.Sprintf("%s:%d:%d => %s", path, line, column, message)))
+}
+
+func newErrHoconSubstitutionNotFound(
+	path string,
+) error {
+	return flam.NewErrorFrom(
+		ErrHoconSubstitutionNotFound,
+		path)
+}
+
+func newErrHoconSubstitutionCycle(
+	path string,
+) error {
+	return flam.NewErrorFrom(
+		ErrHoconSubstitutionCycle,
+		path)
+}
+
+func newErrHoconInvalidConcatenation(
+	path string,
+) error {
+	return flam.NewErrorFrom(
+		ErrHoconInvalidConcatenation,
+		path)
+}
+
+func newErrHoconDiskNotFound(
+	path string,
+) error {
+	return flam.NewErrorFrom(
+		ErrHoconDiskNotFound,
+		path)
+}
+
+func newErrHoconIncludeCycle(
+	path string,
+) error {
+	return flam.NewErrorFrom(
+		ErrHoconIncludeCycle,
+		path)
+}
+
+func newErrJsonnetDiskNotFound(
+	path string,
+) error {
+	return flam.NewErrorFrom(
+		ErrJsonnetDiskNotFound,
+		path)
+}
+
+func newErrJsonnetImportNotFound(
+	path string,
+) error {
+	return flam.NewErrorFrom(
+		ErrJsonnetImportNotFound,
+		path)
+}
+
+func newErrCueEvaluation(
+	details string,
+) error {
+	return flam.NewErrorFrom(
+		ErrCueEvaluation,
+		details)
+}
+
+func newErrCueSchemaDiskNotFound(
+	path string,
+) error {
+	return flam.NewErrorFrom(
+		ErrCueSchemaDiskNotFound,
+		path)
+}
+
+func newErrStarlarkExecution(
+	details string,
+) error {
+	return flam.NewErrorFrom(
+		ErrStarlarkExecution,
+		details)
+}
+
+func newErrStarlarkDiskNotFound(
+	path string,
+) error {
+	return flam.NewErrorFrom(
+		ErrStarlarkDiskNotFound,
+		path)
+}
+
+func newErrStarlarkLoadCycle(
+	path string,
+) error {
+	return flam.NewErrorFrom(
+		ErrStarlarkLoadCycle,
+		path)
+}
+
+func newErrStarlarkInvalidValue(
+	path string,
+	typeName string,
+) error {
+	return flam.NewErrorFrom(
+		ErrStarlarkInvalidValue,
+		fmt.Sprintf("%v => %v", path, typeName))
+}
+
+func newErrMessagePackInvalidRoot(
+	typeName string,
+) error {
+	return flam.NewErrorFrom(
+		ErrMessagePackInvalidRoot,
+		typeName)
+}
+
+func newErrParserDocumentTooLarge(
+	size int,
+) error {
+	return flam.NewErrorFrom(
+		ErrParserDocumentTooLarge,
+		fmt.Sprintf("%d", size))
+}
+
+func newErrParserMaxDepthExceeded(
+	path string,
+	depth int,
+) error {
+	return flam.NewErrorFrom(
+		ErrParserMaxDepthExceeded,
+		fmt.Sprintf("%s => %d", path, depth))
+}
+
+func newErrParserDuplicateKey(
+	path string,
+) error {
+	return flam.NewErrorFrom(
+		ErrParserDuplicateKey,
+		path)
+}
+
+func newErrParserUnknownField(
+	path string,
+) error {
+	return flam.NewErrorFrom(
+		ErrParserUnknownField,
+		path)
+}
+
+func newErrParserNotFoundForExtension(
+	path string,
+) error {
+	return flam.NewErrorFrom(
+		ErrParserNotFoundForExtension,
+		path)
+}
+
+func newErrParserNotFoundForContentType(
+	contentType string,
+) error {
+	return flam.NewErrorFrom(
+		ErrParserNotFoundForContentType,
+		contentType)
+}
+
+func newErrDecompressedSizeExceeded(
+	limit int,
+) error {
+	return flam.NewErrorFrom(
+		ErrDecompressedSizeExceeded,
+		fmt.Sprintf("%d", limit))
+}
+
+func newErrUnsupportedContentEncoding(
+	encoding string,
+) error {
+	return flam.NewErrorFrom(
+		ErrUnsupportedContentEncoding,
+		encoding)
+}
+
+func newErrTemplateRender(
+	details string,
+) error {
+	return flam.NewErrorFrom(
+		ErrTemplateRender,
+		details)
+}
+
+func newErrTemplateRequiredValue(
+	msg string,
+) error {
+	return flam.NewErrorFrom(
+		ErrTemplateRequiredValue,
+		msg)
+}
+
+func newErrSopsInvalidDocument(
+	typeName string,
+) error {
+	return flam.NewErrorFrom(
+		ErrSopsInvalidDocument,
+		typeName)
+}
+
+func newErrSopsDataKeyNotFound(
+	recipients string,
+) error {
+	return flam.NewErrorFrom(
+		ErrSopsDataKeyNotFound,
+		recipients)
+}
+
+func newErrSopsMacMismatch(
+	lastModified string,
+) error {
+	return flam.NewErrorFrom(
+		ErrSopsMacMismatch,
+		lastModified)
+}
+
+func newErrSopsInvalidValue(
+	value string,
+) error {
+	return flam.NewErrorFrom(
+		ErrSopsInvalidValue,
+		value)
+}
+
+func newErrSopsDecryption(
+	path string,
+) error {
+	return flam.NewErrorFrom(
+		ErrSopsDecryption,
+		path)
+}
+
+func newErrSopsKeyDiskNotFound(
+	path string,
+) error {
+	return flam.NewErrorFrom(
+		ErrSopsKeyDiskNotFound,
+		path)
+}
+
+func newErrSecretInvalidKey(
+	id string,
+) error {
+	return flam.NewErrorFrom(
+		ErrSecretInvalidKey,
+		id)
+}
+
+func newErrSecretKeyNotFound(
+	id string,
+) error {
+	return flam.NewErrorFrom(
+		ErrSecretKeyNotFound,
+		id)
+}
+
+func newErrSecretInvalidValue(
+	value string,
+) error {
+	return flam.NewErrorFrom(
+		ErrSecretInvalidValue,
+		value)
+}
+
+func newErrSecretDecryption(
+	id string,
+) error {
+	return flam.NewErrorFrom(
+		ErrSecretDecryption,
+		id)
+}
+
+func newErrSecretKeyringDiskNotFound(
+	path string,
+) error {
+	return flam.NewErrorFrom(
+		ErrSecretKeyringDiskNotFound,
+		path)
+}
+
+func newErrFlagsInvalidType(
+	name string,
+	typeName string,
+) error {
+	return flam.NewErrorFrom(
+		ErrFlagsInvalidType,
+		fmt.Sprintf("%v => %v", name, typeName))
+}
+
+func newErrEnvInvalidCase(
+	caseRule string,
+) error {
+	return flam.NewErrorFrom(
+		ErrEnvInvalidCase,
+		caseRule)
+}
+
+func newErrEnvInvalidType(
+	key string,
+	typeName string,
+) error {
+	return flam.NewErrorFrom(
+		ErrEnvInvalidType,
+		fmt.Sprintf("%v => %v", key, typeName))
+}
+
+func newErrEnvInvalidValue(
+	key string,
+	typeName string,
+) error {
+	return flam.NewErrorFrom(
+		ErrEnvInvalidValue,
+		fmt.Sprintf("%v => %v", key, typeName))
+}
+
+func newErrEnvFileDiskNotFound(
+	path string,
+) error {
+	return flam.NewErrorFrom(
+		ErrEnvFileDiskNotFound,
+		path)
+}
+
+func newErrFlagsHelp(
+	usage string,
+) error {
+	return flam.NewErrorFrom(
+		ErrFlagsHelp,
+		usage)
+}
+
+func newErrParserStrictWithoutFields(
+	driver string,
+) error {
+	return flam.NewErrorFrom(
+		ErrParserStrictWithoutFields,
+		driver)
+}
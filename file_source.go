@@ -1,6 +1,8 @@
 package config
 
 import (
+	"bytes"
+	"io"
 	"os"
 	"sync"
 
@@ -11,26 +13,34 @@ import (
 type fileSource struct {
 	source
 
-	disk   filesystem.Disk
-	path   string
-	parser Parser
+	disk                filesystem.Disk
+	path                string
+	parser              Parser
+	maxDecompressedSize int
+	templateRenderer    *templateRenderer
 }
 
 func newFileSource(
+	id string,
 	priority int,
 	disk filesystem.Disk,
 	path string,
 	parser Parser,
+	maxDecompressedSize int,
+	templateRenderer *templateRenderer,
 ) (Source, error) {
 	source := &fileSource{
 		source: source{
+			id:       id,
 			mutex:    &sync.Mutex{},
 			bag:      flam.Bag{},
 			priority: priority,
 		},
-		disk:   disk,
-		path:   path,
-		parser: parser,
+		disk:                disk,
+		path:                path,
+		parser:              parser,
+		maxDecompressedSize: maxDecompressedSize,
+		templateRenderer:    templateRenderer,
 	}
 
 	if e := source.load(); e != nil {
@@ -47,10 +57,24 @@ func (source *fileSource) load() error {
 	}
 	defer func() { _ = file.Close() }()
 
-	bag, e := source.parser.Parse(file)
+	reader, e := decompressFile(file, source.path, source.maxDecompressedSize)
 	if e != nil {
 		return e
 	}
+	defer func() { _ = reader.Close() }()
+
+	var rendered io.Reader = reader
+	if source.templateRenderer != nil {
+		if rendered, e = source.templateRenderer.Render(source.id, source.path, reader); e != nil {
+			return e
+		}
+	}
+
+	content := &bytes.Buffer{}
+	bag, e := parseFrom(source.parser, source.disk, source.path, io.TeeReader(rendered, content))
+	if e != nil {
+		return newParseError(source.id, source.path, content.Bytes(), e)
+	}
 
 	source.mutex.Lock()
 	defer source.mutex.Unlock()
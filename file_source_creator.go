@@ -8,15 +8,21 @@ import (
 type fileSourceCreator struct {
 	fileSystemFacade filesystem.Facade
 	parserFactory    parserFactory
+	parserRegistry   *parserRegistry
+	templateRenderer *templateRenderer
 }
 
 func newFileSourceCreator(
 	fileSystemFacade filesystem.Facade,
 	parserFactory parserFactory,
+	parserRegistry *parserRegistry,
+	templateRenderer *templateRenderer,
 ) SourceCreator {
 	return &fileSourceCreator{
 		fileSystemFacade: fileSystemFacade,
 		parserFactory:    parserFactory,
+		parserRegistry:   parserRegistry,
+		templateRenderer: templateRenderer,
 	}
 }
 
@@ -36,15 +42,22 @@ func (creator fileSourceCreator) Create(
 		return nil, e
 	}
 
-	parserId := config.String("parser", DefaultFileParser)
-	parser, e := creator.parserFactory.Get(parserId)
+	var parser Parser
+	if parserId := config.String("parser"); parserId != "" {
+		parser, e = creator.parserFactory.Get(parserId)
+	} else {
+		parser, e = creator.parserRegistry.ForPath(config.String("path"))
+	}
 	if e != nil {
 		return nil, e
 	}
 
 	return newFileSource(
+		config.String("id"),
 		config.Int("priority"),
 		disk,
 		config.String("path"),
-		parser)
+		parser,
+		config.Int("max_decompressed_size", DefaultMaxDecompressedSize),
+		creator.templateRenderer.forSource(config))
 }
@@ -0,0 +1,166 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"sort"
+	"strings"
+	"sync"
+
+	flam "github.com/happyhippyhippo/flam"
+)
+
+type flagMapping struct {
+	path  string
+	kind  string
+	usage string
+}
+
+type flagListValue []string
+
+func (value *flagListValue) String() string {
+	return strings.Join(*value, ",")
+}
+
+func (value *flagListValue) Set(
+	str string,
+) error {
+	*value = append(*value, strings.Split(str, ",")...)
+	return nil
+}
+
+type flagsSource struct {
+	source
+
+	args     []string
+	mappings map[string]flagMapping
+}
+
+func newFlagsSource(
+	id string,
+	priority int,
+	args []string,
+	mappings map[string]flagMapping,
+) (Source, error) {
+	source := &flagsSource{
+		source: source{
+			id:       id,
+			mutex:    &sync.Mutex{},
+			bag:      flam.Bag{},
+			priority: priority,
+		},
+		args:     args,
+		mappings: mappings,
+	}
+
+	if e := source.load(); e != nil {
+		return nil, e
+	}
+
+	return source, nil
+}
+
+func (source *flagsSource) load() error {
+	flagSet := flag.NewFlagSet(source.id, flag.ContinueOnError)
+	usage := &bytes.Buffer{}
+	flagSet.SetOutput(usage)
+
+	names := make([]string, 0, len(source.mappings))
+	for name := range source.mappings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	values := map[string]func() any{}
+	for _, name := range names {
+		mapping := source.mappings[name]
+		switch mapping.kind {
+		case "", "string":
+			value := flagSet.String(name, "", mapping.usage)
+			values[name] = func() any { return *value }
+		case "bool":
+			value := flagSet.Bool(name, false, mapping.usage)
+			values[name] = func() any { return *value }
+		case "int":
+			value := flagSet.Int(name, 0, mapping.usage)
+			values[name] = func() any { return *value }
+		case "float":
+			value := flagSet.Float64(name, 0, mapping.usage)
+			values[name] = func() any { return *value }
+		case "duration":
+			value := flagSet.Duration(name, 0, mapping.usage)
+			values[name] = func() any { return *value }
+		case "list":
+			value := &flagListValue{}
+			flagSet.Var(value, name, mapping.usage)
+			values[name] = func() any {
+				list := []any{}
+				for _, item := range *value {
+					list = append(list, item)
+				}
+				return list
+			}
+		default:
+			return newErrFlagsInvalidType(name, mapping.kind)
+		}
+	}
+
+	if e := flagSet.Parse(source.owned(flagSet)); e != nil {
+		if errors.Is(e, flag.ErrHelp) {
+			return newErrFlagsHelp(usage.String())
+		}
+		return e
+	}
+
+	bag := flam.Bag{}
+	var e error
+	flagSet.Visit(func(f *flag.Flag) {
+		if e == nil {
+			e = bag.Set(source.mappings[f.Name].path, values[f.Name]())
+		}
+	})
+	if e != nil {
+		return e
+	}
+
+	source.mutex.Lock()
+	source.bag = bag
+	source.mutex.Unlock()
+
+	return nil
+}
+
+func (source *flagsSource) owned(
+	flagSet *flag.FlagSet,
+) []string {
+	args := []string{}
+	for i := 0; i < len(source.args); i++ {
+		arg := source.args[i]
+		if arg == "--" {
+			break
+		}
+		if len(arg) < 2 || arg[0] != '-' {
+			continue
+		}
+
+		name := strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-")
+		name, _, assigned := strings.Cut(name, "=")
+		f := flagSet.Lookup(name)
+		if f == nil {
+			if name == "h" || name == "help" {
+				args = append(args, arg)
+			}
+			continue
+		}
+
+		args = append(args, arg)
+		boolFlag, ok := f.Value.(interface{ IsBoolFlag() bool })
+		if !assigned && (!ok || !boolFlag.IsBoolFlag()) && i+1 < len(source.args) {
+			i++
+			args = append(args, source.args[i])
+		}
+	}
+
+	return args
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"os"
+
+	flam "github.com/happyhippyhippo/flam"
+)
+
+type flagsSourceCreator struct{}
+
+func newFlagsSourceCreator() SourceCreator {
+	return &flagsSourceCreator{}
+}
+
+func (flagsSourceCreator) Accept(
+	config flam.Bag,
+) bool {
+	return config.String("driver") == SourceDriverFlags
+}
+
+func (flagsSourceCreator) Create(
+	config flam.Bag,
+) (Source, error) {
+	mappings := map[string]flagMapping{}
+	for name, value := range config.Bag("mappings") {
+		switch mapping := value.(type) {
+		case string:
+			mappings[name] = flagMapping{path: mapping}
+		case flam.Bag:
+			mappings[name] = flagMapping{
+				path:  mapping.String("path", name),
+				kind:  mapping.String("type"),
+				usage: mapping.String("usage"),
+			}
+		}
+	}
+
+	args := os.Args[1:]
+	if config.Has("args") {
+		args = config.StringSlice("args", []string{})
+		for _, arg := range config.Slice("args") {
+			if str, ok := arg.(string); ok {
+				args = append(args, str)
+			}
+		}
+	}
+
+	return newFlagsSource(
+		config.String("id"),
+		config.Int("priority"),
+		args,
+		mappings)
+}
@@ -0,0 +1,257 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+
+	flam "github.com/happyhippyhippo/flam"
+)
+
+type hclParser struct {
+	context *hcl.EvalContext
+}
+
+func newHclParser(
+	variables flam.Bag,
+) Parser {
+	parser := &hclParser{}
+	if variables != nil {
+		parser.context = &hcl.EvalContext{
+			Variables: map[string]cty.Value{
+				"var": hclValueOf(variables),
+			},
+		}
+	}
+
+	return parser
+}
+
+func (parser hclParser) Close() error {
+	return nil
+}
+
+func (parser hclParser) Parse(
+	reader io.Reader,
+) (flam.Bag, error) {
+	b, e := io.ReadAll(reader)
+	if e != nil {
+		return nil, e
+	}
+
+	file, diags := hclsyntax.ParseConfig(b, "", hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	data, e := parser.body(file.Body.(*hclsyntax.Body))
+	if e != nil {
+		return nil, e
+	}
+
+	return Convert(data).(flam.Bag), nil
+}
+
+func (parser hclParser) body(
+	body *hclsyntax.Body,
+) (flam.Bag, error) {
+	data := flam.Bag{}
+	for name, attribute := range body.Attributes {
+		if e := parser.check(attribute.Expr); e != nil {
+			return nil, e
+		}
+
+		value, diags := attribute.Expr.Value(parser.context)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+
+		converted, e := hclValueTo(value, attribute.Expr.Range())
+		if e != nil {
+			return nil, e
+		}
+
+		data[name] = converted
+	}
+
+	for _, block := range body.Blocks {
+		inner, e := parser.body(block.Body)
+		if e != nil {
+			return nil, e
+		}
+
+		step := data
+		keys := append([]string{block.Type}, block.Labels...)
+		for _, key := range keys[:len(keys)-1] {
+			var next flam.Bag
+			switch current := step[key].(type) {
+			case nil:
+				next = flam.Bag{}
+				step[key] = next
+			case flam.Bag:
+				next = current
+			default:
+				return nil, newErrHclBlockConflict(block.DefRange())
+			}
+
+			step = next
+		}
+
+		key := keys[len(keys)-1]
+		switch current := step[key].(type) {
+		case nil:
+			step[key] = inner
+		case []any:
+			step[key] = append(current, inner)
+		default:
+			step[key] = []any{current, inner}
+		}
+	}
+
+	return data, nil
+}
+
+func (parser hclParser) check(
+	expr hclsyntax.Expression,
+) error {
+	var e error
+	_ = hclsyntax.VisitAll(expr, func(node hclsyntax.Node) hcl.Diagnostics {
+		if e != nil {
+			return nil
+		}
+
+		switch node.(type) {
+		case *hclsyntax.LiteralValueExpr,
+			*hclsyntax.TemplateExpr,
+			*hclsyntax.TemplateWrapExpr,
+			*hclsyntax.TupleConsExpr,
+			*hclsyntax.ObjectConsExpr,
+			*hclsyntax.ObjectConsKeyExpr,
+			*hclsyntax.UnaryOpExpr:
+		case *hclsyntax.ScopeTraversalExpr:
+			if parser.context == nil {
+				e = newErrHclInvalidExpression(node.Range())
+			}
+		default:
+			e = newErrHclInvalidExpression(node.Range())
+		}
+
+		return nil
+	})
+
+	return e
+}
+
+func hclValueOf(
+	value any,
+) cty.Value {
+	switch typed := value.(type) {
+	case nil:
+		return cty.NullVal(cty.DynamicPseudoType)
+	case string:
+		return cty.StringVal(typed)
+	case bool:
+		return cty.BoolVal(typed)
+	case float32:
+		return cty.NumberFloatVal(float64(typed))
+	case float64:
+		return cty.NumberFloatVal(typed)
+	case []any:
+		if len(typed) == 0 {
+			return cty.EmptyTupleVal
+		}
+
+		var list []cty.Value
+		for _, item := range typed {
+			list = append(list, hclValueOf(item))
+		}
+
+		return cty.TupleVal(list)
+	case flam.Bag:
+		if len(typed) == 0 {
+			return cty.EmptyObjectVal
+		}
+
+		fields := map[string]cty.Value{}
+		for key, item := range typed {
+			fields[key] = hclValueOf(item)
+		}
+
+		return cty.ObjectVal(fields)
+	}
+
+	reflected := reflect.ValueOf(value)
+	switch {
+	case reflected.CanInt():
+		return cty.NumberIntVal(reflected.Int())
+	case reflected.CanUint():
+		return cty.NumberUIntVal(reflected.Uint())
+	}
+
+	return cty.StringVal(fmt.Sprintf("%v", value))
+}
+
+func hclValueTo(
+	value cty.Value,
+	rng hcl.Range,
+) (any, error) {
+	if value.IsNull() {
+		return nil, nil
+	}
+
+	if !value.IsWhollyKnown() {
+		return nil, newErrHclInvalidExpression(rng)
+	}
+
+	valueType := value.Type()
+	switch {
+	case valueType == cty.String:
+		return value.AsString(), nil
+	case valueType == cty.Bool:
+		return value.True(), nil
+	case valueType == cty.Number:
+		number := value.AsBigFloat()
+		if number.IsInt() {
+			if i, accuracy := number.Int64(); accuracy == big.Exact {
+				return int(i), nil
+			}
+		}
+
+		f, _ := number.Float64()
+
+		return f, nil
+	case valueType.IsTupleType() || valueType.IsListType() || valueType.IsSetType():
+		list := []any{}
+		for it := value.ElementIterator(); it.Next(); {
+			_, item := it.Element()
+			converted, e := hclValueTo(item, rng)
+			if e != nil {
+				return nil, e
+			}
+
+			list = append(list, converted)
+		}
+
+		return list, nil
+	case valueType.IsObjectType() || valueType.IsMapType():
+		bag := flam.Bag{}
+		for it := value.ElementIterator(); it.Next(); {
+			key, item := it.Element()
+			converted, e := hclValueTo(item, rng)
+			if e != nil {
+				return nil, e
+			}
+
+			bag[key.AsString()] = converted
+		}
+
+		return bag, nil
+	}
+
+	return nil, newErrHclInvalidExpression(rng)
+}
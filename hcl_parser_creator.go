@@ -0,0 +1,23 @@
+package config
+
+import (
+	flam "github.com/happyhippyhippo/flam"
+)
+
+type hclParserCreator struct{}
+
+func newHclParserCreator() ParserCreator {
+	return &hclParserCreator{}
+}
+
+func (hclParserCreator) Accept(
+	config flam.Bag,
+) bool {
+	return config.String("driver") == ParserDriverHcl
+}
+
+func (hclParserCreator) Create(
+	config flam.Bag,
+) (Parser, error) {
+	return newHclParser(config.Bag("variables")), nil
+}
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"io"
+	"os"
+	"path"
+	"slices"
+
+	flam "github.com/happyhippyhippo/flam"
+	filesystem "github.com/happyhippyhippo/flam-filesystem"
+)
+
+type hoconParser struct {
+	units bool
+}
+
+func newHoconParser(
+	units bool,
+) Parser {
+	return &hoconParser{
+		units: units,
+	}
+}
+
+func (parser *hoconParser) Close() error {
+	return nil
+}
+
+func (parser *hoconParser) Parse(
+	reader io.Reader,
+) (flam.Bag, error) {
+	return parser.ParseFrom(nil, "", reader)
+}
+
+func (parser *hoconParser) ParseFrom(
+	disk filesystem.Disk,
+	path string,
+	reader io.Reader,
+) (flam.Bag, error) {
+	b, e := io.ReadAll(reader)
+	if e != nil {
+		return nil, e
+	}
+
+	root, e := parser.document(disk, path, b, []string{path})
+	if e != nil {
+		return nil, e
+	}
+
+	resolved, e := newHoconResolver(root).resolve()
+	if e != nil {
+		return nil, e
+	}
+
+	return Convert(resolved).(flam.Bag), nil
+}
+
+func (parser *hoconParser) document(
+	disk filesystem.Disk,
+	path string,
+	content []byte,
+	chain []string,
+) (hoconObject, error) {
+	reader := &hoconReader{
+		units: parser.units,
+		input: []rune(string(content)),
+		line:  1,
+		path:  path,
+		include: func(target string, required bool) (hoconObject, error) {
+			return parser.include(disk, path, target, required, chain)
+		},
+	}
+
+	return reader.root()
+}
+
+func (parser *hoconParser) include(
+	disk filesystem.Disk,
+	current string,
+	target string,
+	required bool,
+	chain []string,
+) (hoconObject, error) {
+	if disk == nil {
+		return nil, newErrHoconDiskNotFound(target)
+	}
+
+	resolved := target
+	if !path.IsAbs(target) && current != "" {
+		resolved = path.Join(path.Dir(current), target)
+	}
+
+	if slices.Contains(chain, resolved) {
+		return nil, newErrHoconIncludeCycle(resolved)
+	}
+
+	file, e := disk.OpenFile(resolved, os.O_RDONLY, 0o644)
+	if e != nil {
+		if !required && os.IsNotExist(e) {
+			return hoconObject{}, nil
+		}
+
+		return nil, e
+	}
+	defer func() { _ = file.Close() }()
+
+	content, e := io.ReadAll(file)
+	if e != nil {
+		return nil, e
+	}
+
+	return parser.document(disk, resolved, content, append(slices.Clone(chain), resolved))
+}
@@ -0,0 +1,24 @@
+package config
+
+import (
+	flam "github.com/happyhippyhippo/flam"
+)
+
+type hoconParserCreator struct{}
+
+func newHoconParserCreator() ParserCreator {
+	return &hoconParserCreator{}
+}
+
+func (hoconParserCreator) Accept(
+	config flam.Bag,
+) bool {
+	return config.String("driver") == ParserDriverHocon
+}
+
+func (hoconParserCreator) Create(
+	config flam.Bag,
+) (Parser, error) {
+	return newHoconParser(
+		config.Bool("units")), nil
+}
@@ -0,0 +1,683 @@
+package config
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type hoconObject map[string]any
+
+type hoconSubstitution struct {
+	path     string
+	optional bool
+}
+
+type hoconConcat struct {
+	parts   []any
+	prev    any
+	hasPrev bool
+}
+
+type hoconSpace string
+
+type hoconText string
+
+var (
+	hoconNumberRegex = regexp.MustCompile(`^-?\d+(\.\d+)?([eE][+-]?\d+)?$`)
+	hoconUnitRegex   = regexp.MustCompile(`^(-?\d+(?:\.\d+)?)\s*([a-zA-Z]+)$`)
+
+	hoconDurationUnits = map[string]time.Duration{
+		"ns": time.Nanosecond, "nano": time.Nanosecond, "nanos": time.Nanosecond, "nanosecond": time.Nanosecond, "nanoseconds": time.Nanosecond,
+		"us": time.Microsecond, "micro": time.Microsecond, "micros": time.Microsecond, "microsecond": time.Microsecond, "microseconds": time.Microsecond,
+		"ms": time.Millisecond, "milli": time.Millisecond, "millis": time.Millisecond, "millisecond": time.Millisecond, "milliseconds": time.Millisecond,
+		"s": time.Second, "second": time.Second, "seconds": time.Second,
+		"m": time.Minute, "minute": time.Minute, "minutes": time.Minute,
+		"h": time.Hour, "hour": time.Hour, "hours": time.Hour,
+		"d": 24 * time.Hour, "day": 24 * time.Hour, "days": 24 * time.Hour,
+	}
+
+	hoconSizeUnits = map[string]float64{
+		"B": 1, "b": 1, "byte": 1, "bytes": 1,
+		"kB": 1e3, "kilobyte": 1e3, "kilobytes": 1e3,
+		"MB": 1e6, "megabyte": 1e6, "megabytes": 1e6,
+		"GB": 1e9, "gigabyte": 1e9, "gigabytes": 1e9,
+		"TB": 1e12, "terabyte": 1e12, "terabytes": 1e12,
+		"K": 1 << 10, "k": 1 << 10, "Ki": 1 << 10, "KiB": 1 << 10, "kibibyte": 1 << 10, "kibibytes": 1 << 10,
+		"M": 1 << 20, "Mi": 1 << 20, "MiB": 1 << 20, "mebibyte": 1 << 20, "mebibytes": 1 << 20,
+		"G": 1 << 30, "g": 1 << 30, "Gi": 1 << 30, "GiB": 1 << 30, "gibibyte": 1 << 30, "gibibytes": 1 << 30,
+		"T": 1 << 40, "t": 1 << 40, "Ti": 1 << 40, "TiB": 1 << 40, "tebibyte": 1 << 40, "tebibytes": 1 << 40,
+	}
+)
+
+type hoconReader struct {
+	units   bool
+	input   []rune
+	pos     int
+	line    int
+	path    string
+	include func(target string, required bool) (hoconObject, error)
+}
+
+func (reader *hoconReader) root() (hoconObject, error) {
+	object := hoconObject{}
+
+	reader.skip(true)
+	if reader.peek() != '{' {
+		if e := reader.object(object, nil, 0); e != nil {
+			return nil, e
+		}
+
+		return object, nil
+	}
+
+	reader.pos++
+	if e := reader.object(object, nil, '}'); e != nil {
+		return nil, e
+	}
+
+	reader.skip(true)
+	if !reader.eof() {
+		return nil, reader.error("unexpected content after the root object")
+	}
+
+	return object, nil
+}
+
+func (reader *hoconReader) object(
+	object hoconObject,
+	prefix []string,
+	closing rune,
+) error {
+	for {
+		reader.skip(true)
+		if reader.peek() == ',' {
+			reader.pos++
+			continue
+		}
+
+		switch {
+		case reader.eof() && closing != 0:
+			return reader.error("unterminated object")
+		case reader.eof():
+			return nil
+		case closing != 0 && reader.peek() == closing:
+			reader.pos++
+			return nil
+		}
+
+		if reader.isInclude() {
+			included, e := reader.includeStatement()
+			if e != nil {
+				return e
+			}
+
+			for key, value := range included {
+				hoconSet(object, key, value)
+			}
+		} else if e := reader.field(object, prefix); e != nil {
+			return e
+		}
+
+		reader.skip(false)
+		switch c := reader.peek(); {
+		case c == ',':
+			reader.pos++
+		case c == '\n', reader.eof(), closing != 0 && c == closing:
+		default:
+			return reader.error("expected a field separator")
+		}
+	}
+}
+
+func (reader *hoconReader) field(
+	object hoconObject,
+	prefix []string,
+) error {
+	keys, e := reader.key()
+	if e != nil {
+		return e
+	}
+
+	reader.skip(false)
+	appending := false
+	switch {
+	case reader.peek() == '{':
+	case reader.peek() == '=' || reader.peek() == ':':
+		reader.pos++
+	case reader.startsWith("+="):
+		appending = true
+		reader.pos += 2
+	default:
+		return reader.error("expected a key separator")
+	}
+
+	reader.skip(false)
+	fullPath := append(append([]string{}, prefix...), keys...)
+	value, e := reader.value(fullPath)
+	if e != nil {
+		return e
+	}
+
+	if appending {
+		value = &hoconConcat{parts: []any{
+			&hoconSubstitution{path: strings.Join(fullPath, "."), optional: true},
+			[]any{value},
+		}}
+	}
+
+	target := object
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := target[key].(hoconObject)
+		if !ok {
+			next = hoconObject{}
+			target[key] = next
+		}
+		target = next
+	}
+
+	hoconSet(target, keys[len(keys)-1], value)
+
+	return nil
+}
+
+func (reader *hoconReader) key() ([]string, error) {
+	var keys []string
+	var current strings.Builder
+	valid := false
+
+	for !reader.eof() {
+		c := reader.peek()
+		switch {
+		case c == '"':
+			text, e := reader.quoted()
+			if e != nil {
+				return nil, e
+			}
+			current.WriteString(text)
+			valid = true
+			continue
+		case c == '.':
+			if !valid {
+				return nil, reader.error("invalid key")
+			}
+			keys = append(keys, current.String())
+			current.Reset()
+			valid = false
+			reader.pos++
+			continue
+		case hoconUnquoted(c) && !reader.startsWith("//"):
+			current.WriteRune(c)
+			valid = true
+			reader.pos++
+			continue
+		}
+
+		break
+	}
+
+	if !valid {
+		return nil, reader.error("invalid key")
+	}
+
+	return append(keys, current.String()), nil
+}
+
+func (reader *hoconReader) value(
+	fullPath []string,
+) (any, error) {
+	var parts []any
+
+	for !reader.eof() {
+		c := reader.peek()
+		if c == '\n' || c == ',' || c == '}' || c == ']' || c == '#' || reader.startsWith("//") {
+			break
+		}
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\r':
+			start := reader.pos
+			for !reader.eof() && (reader.peek() == ' ' || reader.peek() == '\t' || reader.peek() == '\r') {
+				reader.pos++
+			}
+			parts = append(parts, hoconSpace(reader.input[start:reader.pos]))
+		case reader.startsWith(`"""`):
+			text, e := reader.multiline()
+			if e != nil {
+				return nil, e
+			}
+			parts = append(parts, text)
+		case c == '"':
+			text, e := reader.quoted()
+			if e != nil {
+				return nil, e
+			}
+			parts = append(parts, text)
+		case reader.startsWith("${"):
+			substitution, e := reader.substitution()
+			if e != nil {
+				return nil, e
+			}
+			parts = append(parts, substitution)
+		case c == '{':
+			reader.pos++
+			object := hoconObject{}
+			if e := reader.object(object, fullPath, '}'); e != nil {
+				return nil, e
+			}
+			parts = append(parts, object)
+		case c == '[':
+			list, e := reader.array(fullPath)
+			if e != nil {
+				return nil, e
+			}
+			parts = append(parts, list)
+		case hoconUnquoted(c):
+			start := reader.pos
+			for !reader.eof() && hoconUnquoted(reader.peek()) && !reader.startsWith("//") {
+				reader.pos++
+			}
+			parts = append(parts, hoconText(reader.input[start:reader.pos]))
+		default:
+			return nil, reader.error("unexpected character '" + string(c) + "'")
+		}
+	}
+
+	for len(parts) != 0 {
+		if _, ok := parts[len(parts)-1].(hoconSpace); !ok {
+			break
+		}
+		parts = parts[:len(parts)-1]
+	}
+
+	if len(parts) == 0 {
+		return nil, reader.error("expected a value")
+	}
+
+	return hoconCombine(parts, reader.units), nil
+}
+
+func (reader *hoconReader) array(
+	fullPath []string,
+) ([]any, error) {
+	reader.pos++
+
+	list := []any{}
+	for {
+		reader.skip(true)
+		if reader.peek() == ',' {
+			reader.pos++
+			continue
+		}
+
+		switch {
+		case reader.eof():
+			return nil, reader.error("unterminated array")
+		case reader.peek() == ']':
+			reader.pos++
+			return list, nil
+		}
+
+		value, e := reader.value(fullPath)
+		if e != nil {
+			return nil, e
+		}
+		list = append(list, value)
+
+		reader.skip(false)
+		switch c := reader.peek(); {
+		case c == ',':
+			reader.pos++
+		case c == '\n', c == ']':
+		default:
+			return nil, reader.error("expected an element separator")
+		}
+	}
+}
+
+func (reader *hoconReader) substitution() (*hoconSubstitution, error) {
+	reader.pos += 2
+
+	substitution := &hoconSubstitution{}
+	if reader.peek() == '?' {
+		substitution.optional = true
+		reader.pos++
+	}
+
+	reader.skip(false)
+	keys, e := reader.key()
+	if e != nil {
+		return nil, e
+	}
+
+	reader.skip(false)
+	if reader.peek() != '}' {
+		return nil, reader.error("unterminated substitution")
+	}
+	reader.pos++
+
+	substitution.path = strings.Join(keys, ".")
+
+	return substitution, nil
+}
+
+func (reader *hoconReader) quoted() (string, error) {
+	reader.pos++
+
+	var builder strings.Builder
+	for !reader.eof() {
+		c := reader.peek()
+		reader.pos++
+
+		switch c {
+		case '"':
+			return builder.String(), nil
+		case '\n':
+			return "", reader.error("unterminated string")
+		case '\\':
+			if reader.eof() {
+				return "", reader.error("unterminated string")
+			}
+
+			escaped := reader.peek()
+			reader.pos++
+			switch escaped {
+			case 'b':
+				builder.WriteRune('\b')
+			case 'f':
+				builder.WriteRune('\f')
+			case 'n':
+				builder.WriteRune('\n')
+			case 'r':
+				builder.WriteRune('\r')
+			case 't':
+				builder.WriteRune('\t')
+			case 'u':
+				if reader.pos+4 > len(reader.input) {
+					return "", reader.error("invalid unicode escape")
+				}
+
+				code, e := strconv.ParseUint(string(reader.input[reader.pos:reader.pos+4]), 16, 32)
+				if e != nil {
+					return "", reader.error("invalid unicode escape")
+				}
+				reader.pos += 4
+
+				builder.WriteRune(rune(code))
+			default:
+				builder.WriteRune(escaped)
+			}
+		default:
+			builder.WriteRune(c)
+		}
+	}
+
+	return "", reader.error("unterminated string")
+}
+
+func (reader *hoconReader) multiline() (string, error) {
+	reader.pos += 3
+
+	start := reader.pos
+	for !reader.eof() {
+		if reader.startsWith(`"""`) {
+			for reader.pos+3 < len(reader.input) && reader.input[reader.pos+3] == '"' {
+				reader.pos++
+			}
+
+			text := string(reader.input[start:reader.pos])
+			reader.pos += 3
+
+			return text, nil
+		}
+
+		if reader.peek() == '\n' {
+			reader.line++
+		}
+		reader.pos++
+	}
+
+	return "", reader.error("unterminated multi-line string")
+}
+
+func (reader *hoconReader) isInclude() bool {
+	if !reader.startsWith("include") {
+		return false
+	}
+
+	i := reader.pos + len("include")
+	if i >= len(reader.input) || (reader.input[i] != ' ' && reader.input[i] != '\t') {
+		return false
+	}
+
+	for i < len(reader.input) && (reader.input[i] == ' ' || reader.input[i] == '\t') {
+		i++
+	}
+
+	rest := string(reader.input[i:])
+
+	return strings.HasPrefix(rest, `"`) ||
+		strings.HasPrefix(rest, "required(") ||
+		strings.HasPrefix(rest, "file(") ||
+		strings.HasPrefix(rest, "url(") ||
+		strings.HasPrefix(rest, "classpath(")
+}
+
+func (reader *hoconReader) includeStatement() (hoconObject, error) {
+	reader.pos += len("include")
+	reader.skip(false)
+
+	required := false
+	if reader.startsWith("required(") {
+		required = true
+		reader.pos += len("required(")
+		reader.skip(false)
+	}
+
+	wrapped := false
+	switch {
+	case reader.startsWith("file("):
+		wrapped = true
+		reader.pos += len("file(")
+		reader.skip(false)
+	case reader.startsWith("url("), reader.startsWith("classpath("):
+		return nil, reader.error("unsupported include resource")
+	}
+
+	if reader.peek() != '"' {
+		return nil, reader.error("expected a quoted include target")
+	}
+
+	target, e := reader.quoted()
+	if e != nil {
+		return nil, e
+	}
+
+	for _, closing := range []bool{wrapped, required} {
+		if !closing {
+			continue
+		}
+
+		reader.skip(false)
+		if reader.peek() != ')' {
+			return nil, reader.error("unterminated include statement")
+		}
+		reader.pos++
+	}
+
+	return reader.include(target, required)
+}
+
+func (reader *hoconReader) skip(
+	newlines bool,
+) {
+	for !reader.eof() {
+		c := reader.peek()
+		switch {
+		case c == '\n' && newlines:
+			reader.line++
+			reader.pos++
+		case c == '\n':
+			return
+		case c == '#' || reader.startsWith("//"):
+			for !reader.eof() && reader.peek() != '\n' {
+				reader.pos++
+			}
+		case unicode.IsSpace(c) || c == '\uFEFF':
+			reader.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (reader *hoconReader) eof() bool {
+	return reader.pos >= len(reader.input)
+}
+
+func (reader *hoconReader) peek() rune {
+	if reader.eof() {
+		return 0
+	}
+
+	return reader.input[reader.pos]
+}
+
+func (reader *hoconReader) startsWith(
+	prefix string,
+) bool {
+	return strings.HasPrefix(string(reader.input[reader.pos:min(len(reader.input), reader.pos+len(prefix))]), prefix)
+}
+
+func (reader *hoconReader) error(
+	message string,
+) error {
+	column := 1
+	for i := reader.pos - 1; i >= 0 && i < len(reader.input) && reader.input[i] != '\n'; i-- {
+		column++
+	}
+
+	return newErrHoconSyntax(reader.path, reader.line, column, message)
+}
+
+func hoconUnquoted(
+	c rune,
+) bool {
+	return c != 0 && !unicode.IsSpace(c) && !strings.ContainsRune("$\"{}[]:=,+#`^?!@*&\\", c)
+}
+
+func hoconSet(
+	target hoconObject,
+	key string,
+	value any,
+) {
+	existing, exists := target[key]
+	switch typed := value.(type) {
+	case hoconObject:
+		switch current := existing.(type) {
+		case hoconObject:
+			for k, v := range typed {
+				hoconSet(current, k, v)
+			}
+			return
+		case *hoconSubstitution, *hoconConcat:
+			target[key] = &hoconConcat{parts: []any{current, typed}}
+			return
+		}
+	case *hoconConcat:
+		if exists && !typed.hasPrev {
+			typed.prev, typed.hasPrev = existing, true
+		}
+	case *hoconSubstitution:
+		if exists {
+			target[key] = &hoconConcat{parts: []any{typed}, prev: existing, hasPrev: true}
+			return
+		}
+	}
+
+	target[key] = value
+}
+
+func hoconCombine(
+	parts []any,
+	units bool,
+) any {
+	text := true
+	for _, part := range parts {
+		switch part.(type) {
+		case hoconText, hoconSpace:
+		default:
+			text = false
+		}
+	}
+
+	if text {
+		var builder strings.Builder
+		for _, part := range parts {
+			switch typed := part.(type) {
+			case hoconText:
+				builder.WriteString(string(typed))
+			case hoconSpace:
+				builder.WriteString(string(typed))
+			}
+		}
+
+		return hoconLiteral(builder.String(), units)
+	}
+
+	if len(parts) == 1 {
+		return parts[0]
+	}
+
+	for i, part := range parts {
+		if typed, ok := part.(hoconText); ok {
+			parts[i] = string(typed)
+		}
+	}
+
+	return &hoconConcat{parts: parts}
+}
+
+func hoconLiteral(
+	text string,
+	units bool,
+) any {
+	switch text {
+	case "true":
+		return true
+	case "false":
+		return false
+	case "null":
+		return nil
+	}
+
+	if hoconNumberRegex.MatchString(text) {
+		if i, e := strconv.Atoi(text); e == nil {
+			return i
+		}
+
+		if f, e := strconv.ParseFloat(text, 64); e == nil {
+			return f
+		}
+	}
+
+	if !units {
+		return text
+	}
+
+	if matches := hoconUnitRegex.FindStringSubmatch(text); matches != nil {
+		amount, _ := strconv.ParseFloat(matches[1], 64)
+		if unit, ok := hoconDurationUnits[matches[2]]; ok {
+			return time.Duration(amount * float64(unit))
+		}
+
+		if unit, ok := hoconSizeUnits[matches[2]]; ok {
+			return int(math.Round(amount * unit))
+		}
+	}
+
+	return text
+}
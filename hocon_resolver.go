@@ -0,0 +1,289 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+type hoconUndefined struct{}
+
+type hoconResolver struct {
+	root      hoconObject
+	resolving map[string]any
+	resolved  map[string]any
+}
+
+func newHoconResolver(
+	root hoconObject,
+) *hoconResolver {
+	return &hoconResolver{
+		root:      root,
+		resolving: map[string]any{},
+		resolved:  map[string]any{},
+	}
+}
+
+func (resolver *hoconResolver) resolve() (map[string]any, error) {
+	value, e := resolver.value(resolver.root, "")
+	if e != nil {
+		return nil, e
+	}
+
+	return value.(map[string]any), nil
+}
+
+func (resolver *hoconResolver) field(
+	path string,
+	raw any,
+) (any, error) {
+	if value, ok := resolver.resolved[path]; ok {
+		return value, nil
+	}
+
+	if _, ok := resolver.resolving[path]; ok {
+		return nil, newErrHoconSubstitutionCycle(path)
+	}
+
+	resolver.resolving[path] = raw
+	value, e := resolver.value(raw, path)
+	delete(resolver.resolving, path)
+	if e != nil {
+		return nil, e
+	}
+
+	resolver.resolved[path] = value
+
+	return value, nil
+}
+
+func (resolver *hoconResolver) value(
+	raw any,
+	path string,
+) (any, error) {
+	switch typed := raw.(type) {
+	case hoconObject:
+		result := map[string]any{}
+		for key, item := range typed {
+			value, e := resolver.field(hoconJoin(path, key), item)
+			if e != nil {
+				return nil, e
+			}
+
+			if _, ok := value.(hoconUndefined); !ok {
+				result[key] = value
+			}
+		}
+
+		return result, nil
+	case []any:
+		result := []any{}
+		for _, item := range typed {
+			value, e := resolver.value(item, path)
+			if e != nil {
+				return nil, e
+			}
+
+			if _, ok := value.(hoconUndefined); !ok {
+				result = append(result, value)
+			}
+		}
+
+		return result, nil
+	case *hoconSubstitution:
+		return resolver.substitute(typed)
+	case *hoconConcat:
+		return resolver.concat(typed, path)
+	}
+
+	return raw, nil
+}
+
+func (resolver *hoconResolver) substitute(
+	substitution *hoconSubstitution,
+) (any, error) {
+	if current, ok := resolver.resolving[substitution.path]; ok {
+		concat, ok := current.(*hoconConcat)
+		switch {
+		case ok && concat.hasPrev:
+			resolver.resolving[substitution.path] = concat.prev
+			value, e := resolver.value(concat.prev, substitution.path)
+			resolver.resolving[substitution.path] = current
+
+			return value, e
+		case substitution.optional:
+			return hoconUndefined{}, nil
+		}
+
+		return nil, newErrHoconSubstitutionCycle(substitution.path)
+	}
+
+	value, found, e := resolver.lookup(substitution.path)
+	if e != nil {
+		return nil, e
+	}
+
+	if found {
+		return value, nil
+	}
+
+	if env, ok := os.LookupEnv(substitution.path); ok {
+		return env, nil
+	}
+
+	if substitution.optional {
+		return hoconUndefined{}, nil
+	}
+
+	return nil, newErrHoconSubstitutionNotFound(substitution.path)
+}
+
+func (resolver *hoconResolver) lookup(
+	path string,
+) (any, bool, error) {
+	var current any = resolver.root
+	prefix := ""
+	keys := strings.Split(path, ".")
+	for i, key := range keys {
+		prefix = hoconJoin(prefix, key)
+
+		var raw any
+		var ok bool
+		switch typed := current.(type) {
+		case hoconObject:
+			raw, ok = typed[key]
+		case map[string]any:
+			raw, ok = typed[key]
+		}
+
+		if !ok {
+			return nil, false, nil
+		}
+
+		if object, ok := raw.(hoconObject); ok && i != len(keys)-1 {
+			current = object
+			continue
+		}
+
+		value, e := resolver.field(prefix, raw)
+		if e != nil {
+			return nil, false, e
+		}
+
+		if _, ok := value.(hoconUndefined); ok {
+			return nil, false, nil
+		}
+
+		current = value
+	}
+
+	return current, true, nil
+}
+
+func (resolver *hoconResolver) concat(
+	concat *hoconConcat,
+	path string,
+) (any, error) {
+	var parts []any
+	for _, part := range concat.parts {
+		if space, ok := part.(hoconSpace); ok {
+			parts = append(parts, space)
+			continue
+		}
+
+		value, e := resolver.value(part, path)
+		if e != nil {
+			return nil, e
+		}
+
+		if _, ok := value.(hoconUndefined); !ok {
+			parts = append(parts, value)
+		}
+	}
+
+	var values []any
+	for _, part := range parts {
+		if _, ok := part.(hoconSpace); !ok {
+			values = append(values, part)
+		}
+	}
+
+	switch len(values) {
+	case 0:
+		return hoconUndefined{}, nil
+	case 1:
+		if _, ok := parts[0].(hoconSpace); !ok && len(parts) == 1 {
+			return values[0], nil
+		}
+	}
+
+	switch values[0].(type) {
+	case map[string]any:
+		result := map[string]any{}
+		for _, value := range values {
+			object, ok := value.(map[string]any)
+			if !ok {
+				return nil, newErrHoconInvalidConcatenation(path)
+			}
+			hoconMerge(result, object)
+		}
+
+		return result, nil
+	case []any:
+		result := []any{}
+		for _, value := range values {
+			list, ok := value.([]any)
+			if !ok {
+				return nil, newErrHoconInvalidConcatenation(path)
+			}
+			result = append(result, list...)
+		}
+
+		return result, nil
+	}
+
+	var builder strings.Builder
+	for _, part := range parts {
+		switch typed := part.(type) {
+		case map[string]any, []any:
+			return nil, newErrHoconInvalidConcatenation(path)
+		case hoconSpace:
+			builder.WriteString(string(typed))
+		case nil:
+		default:
+			builder.WriteString(fmt.Sprintf("%v", typed))
+		}
+	}
+
+	return builder.String(), nil
+}
+
+func hoconMerge(
+	target map[string]any,
+	source map[string]any,
+) {
+	for key, value := range source {
+		if object, ok := value.(map[string]any); ok {
+			if current, ok := target[key].(map[string]any); ok {
+				merged := map[string]any{}
+				hoconMerge(merged, current)
+				hoconMerge(merged, object)
+				target[key] = merged
+				continue
+			}
+		}
+
+		target[key] = value
+	}
+}
+
+func hoconJoin(
+	prefix string,
+	key string,
+) string {
+	if prefix == "" {
+		return key
+	}
+
+	return prefix + "." + key
+}
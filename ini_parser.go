@@ -0,0 +1,146 @@
+package config
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	flam "github.com/happyhippyhippo/flam"
+)
+
+type iniParser struct{}
+
+func newIniParser() Parser {
+	return &iniParser{}
+}
+
+func (parser iniParser) Close() error {
+	return nil
+}
+
+func (parser iniParser) Parse(
+	reader io.Reader,
+) (flam.Bag, error) {
+	data := flam.Bag{}
+	section := ""
+
+	scanner := bufio.NewScanner(reader)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == ';' || line[0] == '#' {
+			continue
+		}
+
+		for parser.continues(line) && scanner.Scan() {
+			lineNumber++
+			line = line[:len(line)-1] + strings.TrimSpace(scanner.Text())
+		}
+
+		if line[0] == '[' {
+			if line[len(line)-1] != ']' {
+				return nil, newErrIniInvalidLine(lineNumber, line)
+			}
+
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if section == "" {
+				return nil, newErrIniInvalidLine(lineNumber, line)
+			}
+
+			continue
+		}
+
+		i := strings.IndexAny(line, "=:")
+		if i <= 0 {
+			return nil, newErrIniInvalidLine(lineNumber, line)
+		}
+
+		key := strings.TrimSpace(line[:i])
+		if section != "" {
+			key = section + "." + key
+		}
+
+		value, e := parser.value(strings.TrimSpace(line[i+1:]))
+		if e != nil {
+			return nil, newErrIniInvalidLine(lineNumber, line)
+		}
+
+		if e := data.Set(strings.ToLower(key), value); e != nil {
+			return nil, e
+		}
+	}
+
+	if e := scanner.Err(); e != nil {
+		return nil, e
+	}
+
+	return Convert(data).(flam.Bag), nil
+}
+
+func (parser iniParser) continues(
+	line string,
+) bool {
+	backslashes := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		backslashes++
+	}
+
+	return backslashes%2 == 1
+}
+
+func (parser iniParser) value(
+	raw string,
+) (string, error) {
+	if len(raw) >= 2 && (raw[0] == '"' || raw[0] == '\'') {
+		for i := 1; i < len(raw); i++ {
+			switch raw[i] {
+			case '\\':
+				i++
+			case raw[0]:
+				if rest := strings.TrimSpace(raw[i+1:]); rest == "" || rest[0] == ';' || rest[0] == '#' {
+					return parser.unescape(raw[1:i], false)
+				}
+				return parser.unescape(raw, true)
+			}
+		}
+	}
+
+	return parser.unescape(raw, true)
+}
+
+func (parser iniParser) unescape(
+	raw string,
+	stripComment bool,
+) (string, error) {
+	var builder strings.Builder
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+		switch {
+		case c == '\\':
+			i++
+			if i == len(raw) {
+				return "", io.ErrUnexpectedEOF
+			}
+
+			switch raw[i] {
+			case 'n':
+				builder.WriteByte('\n')
+			case 'r':
+				builder.WriteByte('\r')
+			case 't':
+				builder.WriteByte('\t')
+			case '0':
+				builder.WriteByte(0)
+			default:
+				builder.WriteByte(raw[i])
+			}
+		case stripComment && (c == ';' || c == '#') && (i == 0 || raw[i-1] == ' ' || raw[i-1] == '\t'):
+			return strings.TrimSpace(builder.String()), nil
+		default:
+			builder.WriteByte(c)
+		}
+	}
+
+	return builder.String(), nil
+}
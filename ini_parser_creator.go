@@ -0,0 +1,23 @@
+package config
+
+import (
+	flam "github.com/happyhippyhippo/flam"
+)
+
+type iniParserCreator struct{}
+
+func newIniParserCreator() ParserCreator {
+	return &iniParserCreator{}
+}
+
+func (iniParserCreator) Accept(
+	config flam.Bag,
+) bool {
+	return config.String("driver") == ParserDriverIni
+}
+
+func (iniParserCreator) Create(
+	_ flam.Bag,
+) (Parser, error) {
+	return newIniParser(), nil
+}
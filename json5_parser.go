@@ -0,0 +1,366 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	flam "github.com/happyhippyhippo/flam"
+)
+
+type json5Parser struct {
+	jsonParser
+}
+
+func newJson5Parser() Parser {
+	return &json5Parser{}
+}
+
+func (parser json5Parser) Parse(
+	reader io.Reader,
+) (flam.Bag, error) {
+	b, e := io.ReadAll(reader)
+	if e != nil {
+		return nil, e
+	}
+
+	transcoder := newJson5Transcoder(b)
+	converted, e := transcoder.transcode()
+	if e != nil {
+		return nil, e
+	}
+
+	bag, e := parser.jsonParser.Parse(bytes.NewReader(converted))
+	if e != nil {
+		return nil, transcoder.locate(e)
+	}
+
+	return bag, nil
+}
+
+type json5Mark struct {
+	output int
+	input  int
+}
+
+type json5Transcoder struct {
+	input  []byte
+	output bytes.Buffer
+	pos    int
+	marks  []json5Mark
+}
+
+func newJson5Transcoder(
+	input []byte,
+) *json5Transcoder {
+	return &json5Transcoder{
+		input: input,
+	}
+}
+
+func (transcoder *json5Transcoder) transcode() ([]byte, error) {
+	for transcoder.pos < len(transcoder.input) {
+		transcoder.marks = append(transcoder.marks, json5Mark{transcoder.output.Len(), transcoder.pos})
+
+		c := transcoder.input[transcoder.pos]
+		switch {
+		case c == '/':
+			if e := transcoder.comment(); e != nil {
+				return nil, e
+			}
+		case c == '"' || c == '\'':
+			if e := transcoder.string(c); e != nil {
+				return nil, e
+			}
+		case c == ',':
+			if last := transcoder.last(); last == 0 || last == '[' || last == '{' || last == ',' || last == ':' {
+				return nil, newErrJson5InvalidToken(transcoder.pos, ",")
+			}
+			transcoder.pos++
+			if next := transcoder.peek(); next != '}' && next != ']' {
+				transcoder.output.WriteByte(',')
+			}
+		case c == '+' || c == '-' || c == '.' || (c >= '0' && c <= '9'):
+			if e := transcoder.number(); e != nil {
+				return nil, e
+			}
+		case c == '_' || c == '$' || unicode.IsLetter(rune(c)) || c >= utf8.RuneSelf:
+			if e := transcoder.identifier(); e != nil {
+				return nil, e
+			}
+		default:
+			transcoder.output.WriteByte(c)
+			transcoder.pos++
+		}
+	}
+
+	return transcoder.output.Bytes(), nil
+}
+
+func (transcoder *json5Transcoder) locate(
+	e error,
+) error {
+	var offset int64
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(e, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(e, &typeErr):
+		offset = typeErr.Offset
+	default:
+		return e
+	}
+
+	i := sort.Search(len(transcoder.marks), func(i int) bool {
+		return int64(transcoder.marks[i].output) > offset
+	}) - 1
+	if i < 0 {
+		return e
+	}
+
+	input := transcoder.marks[i].input + int(offset) - transcoder.marks[i].output
+	if i+1 < len(transcoder.marks) && input > transcoder.marks[i+1].input {
+		input = transcoder.marks[i+1].input
+	}
+
+	line, column := parseErrorOffsetPosition(transcoder.input, int64(input))
+
+	return newPositionError(line, column, e)
+}
+
+func (transcoder *json5Transcoder) comment() error {
+	start := transcoder.pos
+	if start+1 >= len(transcoder.input) {
+		return newErrJson5InvalidToken(start, "/")
+	}
+
+	switch transcoder.input[start+1] {
+	case '/':
+		end := bytes.IndexByte(transcoder.input[start:], '\n')
+		if end == -1 {
+			transcoder.pos = len(transcoder.input)
+		} else {
+			transcoder.pos = start + end
+		}
+	case '*':
+		end := bytes.Index(transcoder.input[start+2:], []byte("*/"))
+		if end == -1 {
+			return newErrJson5InvalidToken(start, "/*")
+		}
+		transcoder.pos = start + 2 + end + 2
+		transcoder.output.WriteByte(' ')
+	default:
+		return newErrJson5InvalidToken(start, "/")
+	}
+
+	return nil
+}
+
+func (transcoder *json5Transcoder) last() byte {
+	output := bytes.TrimRight(transcoder.output.Bytes(), " \t\r\n")
+	if len(output) == 0 {
+		return 0
+	}
+
+	return output[len(output)-1]
+}
+
+func (transcoder *json5Transcoder) peek() byte {
+	for i := transcoder.pos; i < len(transcoder.input); i++ {
+		switch c := transcoder.input[i]; {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+		case bytes.HasPrefix(transcoder.input[i:], []byte("//")):
+			end := bytes.IndexByte(transcoder.input[i:], '\n')
+			if end == -1 {
+				return 0
+			}
+			i += end
+		case bytes.HasPrefix(transcoder.input[i:], []byte("/*")):
+			end := bytes.Index(transcoder.input[i+2:], []byte("*/"))
+			if end == -1 {
+				return 0
+			}
+			i += 2 + end + 1
+		default:
+			return c
+		}
+	}
+
+	return 0
+}
+
+func (transcoder *json5Transcoder) string(
+	quote byte,
+) error {
+	start := transcoder.pos
+	transcoder.pos++
+
+	var builder strings.Builder
+	for transcoder.pos < len(transcoder.input) {
+		c := transcoder.input[transcoder.pos]
+		transcoder.pos++
+
+		switch c {
+		case quote:
+			encoded, e := json.Marshal(builder.String())
+			if e != nil {
+				return e
+			}
+			transcoder.output.Write(encoded)
+			return nil
+		case '\n':
+			return newErrJson5InvalidToken(start, string(quote))
+		case '\\':
+			if transcoder.pos >= len(transcoder.input) {
+				return newErrJson5InvalidToken(start, string(quote))
+			}
+
+			escaped := transcoder.input[transcoder.pos]
+			transcoder.pos++
+			switch escaped {
+			case '\n':
+			case '\r':
+				if transcoder.pos < len(transcoder.input) && transcoder.input[transcoder.pos] == '\n' {
+					transcoder.pos++
+				}
+			case 'b':
+				builder.WriteByte('\b')
+			case 'f':
+				builder.WriteByte('\f')
+			case 'n':
+				builder.WriteByte('\n')
+			case 'r':
+				builder.WriteByte('\r')
+			case 't':
+				builder.WriteByte('\t')
+			case 'v':
+				builder.WriteByte('\v')
+			case '0':
+				builder.WriteByte(0)
+			case 'x':
+				r, e := transcoder.codepoint(escaped, 2)
+				if e != nil {
+					return e
+				}
+
+				builder.WriteRune(r)
+			case 'u':
+				r, e := transcoder.codepoint(escaped, 4)
+				if e != nil {
+					return e
+				}
+
+				if utf16.IsSurrogate(r) && bytes.HasPrefix(transcoder.input[transcoder.pos:], []byte("\\u")) {
+					transcoder.pos += 2
+					if low, e := transcoder.codepoint(escaped, 4); e == nil {
+						r = utf16.DecodeRune(r, low)
+					} else {
+						transcoder.pos -= 2
+					}
+				}
+
+				builder.WriteRune(r)
+			default:
+				builder.WriteByte(escaped)
+			}
+		default:
+			builder.WriteByte(c)
+		}
+	}
+
+	return newErrJson5InvalidToken(start, string(quote))
+}
+
+func (transcoder *json5Transcoder) codepoint(
+	escaped byte,
+	size int,
+) (rune, error) {
+	if transcoder.pos+size > len(transcoder.input) {
+		return 0, newErrJson5InvalidToken(transcoder.pos-2, "\\"+string(escaped))
+	}
+
+	code, e := strconv.ParseUint(string(transcoder.input[transcoder.pos:transcoder.pos+size]), 16, 32)
+	if e != nil {
+		return 0, newErrJson5InvalidToken(transcoder.pos-2, "\\"+string(escaped))
+	}
+	transcoder.pos += size
+
+	return rune(code), nil
+}
+
+func (transcoder *json5Transcoder) number() error {
+	start := transcoder.pos
+	for transcoder.pos < len(transcoder.input) {
+		c := transcoder.input[transcoder.pos]
+		if !strings.ContainsRune("+-.0123456789abcdefABCDEFxX", rune(c)) {
+			break
+		}
+		transcoder.pos++
+	}
+
+	token := string(transcoder.input[start:transcoder.pos])
+	sign := ""
+	switch {
+	case strings.HasPrefix(token, "-"):
+		sign = "-"
+		token = token[1:]
+	case strings.HasPrefix(token, "+"):
+		token = token[1:]
+	}
+
+	if strings.HasPrefix(token, "0x") || strings.HasPrefix(token, "0X") {
+		value, e := strconv.ParseUint(token[2:], 16, 64)
+		if e != nil {
+			return newErrJson5InvalidToken(start, token)
+		}
+		transcoder.output.WriteString(sign + strconv.FormatUint(value, 10))
+
+		return nil
+	}
+
+	if token == "" {
+		return newErrJson5InvalidToken(start, sign)
+	}
+
+	if strings.HasPrefix(token, ".") {
+		token = "0" + token
+	}
+	token = strings.Replace(token, ".e", ".0e", 1)
+	token = strings.Replace(token, ".E", ".0E", 1)
+	token = strings.TrimSuffix(token, ".")
+
+	transcoder.output.WriteString(sign + token)
+
+	return nil
+}
+
+func (transcoder *json5Transcoder) identifier() error {
+	start := transcoder.pos
+	for transcoder.pos < len(transcoder.input) {
+		r, size := utf8.DecodeRune(transcoder.input[transcoder.pos:])
+		if r != '_' && r != '$' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			break
+		}
+		transcoder.pos += size
+	}
+
+	token := string(transcoder.input[start:transcoder.pos])
+	switch {
+	case token == "true" || token == "false" || token == "null":
+		transcoder.output.WriteString(token)
+	case token != "" && transcoder.peek() == ':':
+		transcoder.output.WriteString(strconv.Quote(token))
+	default:
+		return newErrJson5InvalidToken(start, token)
+	}
+
+	return nil
+}
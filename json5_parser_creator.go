@@ -0,0 +1,23 @@
+package config
+
+import (
+	flam "github.com/happyhippyhippo/flam"
+)
+
+type json5ParserCreator struct{}
+
+func newJson5ParserCreator() ParserCreator {
+	return &json5ParserCreator{}
+}
+
+func (json5ParserCreator) Accept(
+	config flam.Bag,
+) bool {
+	return config.String("driver") == ParserDriverJson5
+}
+
+func (json5ParserCreator) Create(
+	_ flam.Bag,
+) (Parser, error) {
+	return newJson5Parser(), nil
+}
@@ -1,16 +1,25 @@
 package config
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"io"
+	"strings"
 
 	flam "github.com/happyhippyhippo/flam"
 )
 
-type jsonParser struct{}
+type jsonParser struct {
+	options parserOptions
+}
 
-func newJsonParser() Parser {
-	return &jsonParser{}
+func newJsonParser(
+	options parserOptions,
+) Parser {
+	return &jsonParser{
+		options: options,
+	}
 }
 
 func (parser jsonParser) Close() error {
@@ -20,15 +29,77 @@ func (parser jsonParser) Close() error {
 func (parser jsonParser) Parse(
 	reader io.Reader,
 ) (flam.Bag, error) {
-	b, e := io.ReadAll(reader)
+	b, e := parser.options.read(reader)
 	if e != nil {
 		return nil, e
 	}
 
+	if parser.options.disallowDuplicateKeys {
+		if e := parser.checkDuplicateKeys(json.NewDecoder(bytes.NewReader(b)), ""); e != nil {
+			return nil, e
+		}
+	}
+
 	data := map[string]any{}
-	if e := json.Unmarshal(b, &data); e != nil {
+	if parser.options.useNumber {
+		decoder := json.NewDecoder(bytes.NewReader(b))
+		decoder.UseNumber()
+		if e := decoder.Decode(&data); e != nil {
+			return nil, e
+		}
+		if len(bytes.TrimSpace(b[decoder.InputOffset():])) != 0 {
+			return nil, json.Unmarshal(b, &map[string]any{})
+		}
+	} else if e := json.Unmarshal(b, &data); e != nil {
 		return nil, e
 	}
 
-	return Convert(data).(flam.Bag), nil
+	bag := Convert(data).(flam.Bag)
+	if e := parser.options.check(bag); e != nil {
+		return nil, e
+	}
+
+	return bag, nil
+}
+
+func (parser jsonParser) checkDuplicateKeys(
+	decoder *json.Decoder,
+	path string,
+) error {
+	token, e := decoder.Token()
+	if e != nil {
+		return e
+	}
+
+	switch token {
+	case json.Delim('{'):
+		keys := map[string]bool{}
+		for decoder.More() {
+			token, e := decoder.Token()
+			if e != nil {
+				return e
+			}
+
+			key := strings.ToLower(fmt.Sprintf("%v", token))
+			current := parser.options.join(path, key)
+			if keys[key] {
+				return newErrParserDuplicateKey(current)
+			}
+			keys[key] = true
+
+			if e := parser.checkDuplicateKeys(decoder, current); e != nil {
+				return e
+			}
+		}
+		_, e = decoder.Token()
+	case json.Delim('['):
+		for i := 0; decoder.More(); i++ {
+			if e := parser.checkDuplicateKeys(decoder, fmt.Sprintf("%s[%d]", path, i)); e != nil {
+				return e
+			}
+		}
+		_, e = decoder.Token()
+	}
+
+	return e
 }
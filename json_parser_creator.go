@@ -17,7 +17,12 @@ func (jsonParserCreator) Accept(
 }
 
 func (jsonParserCreator) Create(
-	_ flam.Bag,
+	config flam.Bag,
 ) (Parser, error) {
-	return newJsonParser(), nil
+	options, e := newParserOptions(config, false)
+	if e != nil {
+		return nil, e
+	}
+
+	return newJsonParser(options), nil
 }
@@ -0,0 +1,162 @@
+package config
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+
+	"github.com/google/go-jsonnet"
+
+	flam "github.com/happyhippyhippo/flam"
+	filesystem "github.com/happyhippyhippo/flam-filesystem"
+)
+
+type jsonnetParser struct {
+	extVars     map[string]any
+	tlaArgs     map[string]any
+	importPaths []string
+}
+
+func newJsonnetParser(
+	extVars map[string]any,
+	tlaArgs map[string]any,
+	importPaths []string,
+) Parser {
+	return &jsonnetParser{
+		extVars:     extVars,
+		tlaArgs:     tlaArgs,
+		importPaths: importPaths,
+	}
+}
+
+func (parser *jsonnetParser) Close() error {
+	return nil
+}
+
+func (parser *jsonnetParser) Parse(
+	reader io.Reader,
+) (flam.Bag, error) {
+	return parser.ParseFrom(nil, "", reader)
+}
+
+func (parser *jsonnetParser) ParseFrom(
+	disk filesystem.Disk,
+	path string,
+	reader io.Reader,
+) (flam.Bag, error) {
+	b, e := io.ReadAll(reader)
+	if e != nil {
+		return nil, e
+	}
+
+	vm := jsonnet.MakeVM()
+	vm.Importer(newJsonnetImporter(disk, path, parser.importPaths))
+
+	for name, value := range parser.extVars {
+		if str, ok := value.(string); ok {
+			vm.ExtVar(name, str)
+			continue
+		}
+
+		code, e := json.Marshal(value)
+		if e != nil {
+			return nil, e
+		}
+		vm.ExtCode(name, string(code))
+	}
+
+	for name, value := range parser.tlaArgs {
+		if str, ok := value.(string); ok {
+			vm.TLAVar(name, str)
+			continue
+		}
+
+		code, e := json.Marshal(value)
+		if e != nil {
+			return nil, e
+		}
+		vm.TLACode(name, string(code))
+	}
+
+	output, e := vm.EvaluateAnonymousSnippet(path, string(b))
+	if e != nil {
+		return nil, e
+	}
+
+	data := map[string]any{}
+	if e := json.Unmarshal([]byte(output), &data); e != nil {
+		return nil, e
+	}
+
+	return Convert(data).(flam.Bag), nil
+}
+
+type jsonnetImporter struct {
+	disk        filesystem.Disk
+	path        string
+	importPaths []string
+	cache       map[string]jsonnet.Contents
+}
+
+func newJsonnetImporter(
+	disk filesystem.Disk,
+	path string,
+	importPaths []string,
+) jsonnet.Importer {
+	return &jsonnetImporter{
+		disk:        disk,
+		path:        path,
+		importPaths: importPaths,
+		cache:       map[string]jsonnet.Contents{},
+	}
+}
+
+func (importer *jsonnetImporter) Import(
+	importedFrom string,
+	importedPath string,
+) (jsonnet.Contents, string, error) {
+	if importer.disk == nil {
+		return jsonnet.Contents{}, "", newErrJsonnetDiskNotFound(importedPath)
+	}
+
+	if importedFrom == "" {
+		importedFrom = importer.path
+	}
+
+	candidates := []string{importedPath}
+	if !path.IsAbs(importedPath) {
+		candidates = []string{path.Join(path.Dir(importedFrom), importedPath)}
+		for _, importPath := range importer.importPaths {
+			candidates = append(candidates, path.Join(importPath, importedPath))
+		}
+	}
+
+	for _, candidate := range candidates {
+		if contents, ok := importer.cache[candidate]; ok {
+			return contents, candidate, nil
+		}
+
+		file, e := importer.disk.OpenFile(candidate, os.O_RDONLY, 0o644)
+		if e != nil {
+			if os.IsNotExist(e) {
+				continue
+			}
+
+			return jsonnet.Contents{}, "", e
+		}
+
+		b, e := io.ReadAll(file)
+		_ = file.Close()
+		if e != nil {
+			return jsonnet.Contents{}, "", e
+		}
+
+		contents := jsonnet.MakeContentsRaw(b)
+		importer.cache[candidate] = contents
+
+		return contents, candidate, nil
+	}
+
+	return jsonnet.Contents{}, "", newErrJsonnetImportNotFound(importedPath)
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	flam "github.com/happyhippyhippo/flam"
+)
+
+type jsonnetParserCreator struct{}
+
+func newJsonnetParserCreator() ParserCreator {
+	return &jsonnetParserCreator{}
+}
+
+func (jsonnetParserCreator) Accept(
+	config flam.Bag,
+) bool {
+	return config.String("driver") == ParserDriverJsonnet
+}
+
+func (jsonnetParserCreator) Create(
+	config flam.Bag,
+) (Parser, error) {
+	var importPaths []string
+	for _, importPath := range config.Slice("import_paths", []any{}) {
+		if str, ok := importPath.(string); ok {
+			importPaths = append(importPaths, str)
+		}
+	}
+	importPaths = append(importPaths, config.StringSlice("import_paths", []string{})...)
+
+	return newJsonnetParser(
+		config.Bag("ext_vars", flam.Bag{}),
+		config.Bag("tla_args", flam.Bag{}),
+		importPaths), nil
+}
@@ -0,0 +1,159 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"strings"
+
+	flam "github.com/happyhippyhippo/flam"
+)
+
+const secretPrefix = "enc:v1:"
+
+type keyring struct {
+	primary string
+	keys    map[string][]byte
+}
+
+func newKeyring(
+	reader io.Reader,
+) (*keyring, error) {
+	content := struct {
+		Primary string            `json:"primary"`
+		Keys    map[string]string `json:"keys"`
+	}{}
+	if e := json.NewDecoder(reader).Decode(&content); e != nil {
+		return nil, e
+	}
+
+	keyring := &keyring{
+		primary: content.Primary,
+		keys:    map[string][]byte{},
+	}
+
+	for id, encoded := range content.Keys {
+		key, e := base64.StdEncoding.DecodeString(encoded)
+		if e != nil {
+			return nil, newErrSecretInvalidKey(id)
+		}
+		if _, e := aes.NewCipher(key); e != nil {
+			return nil, newErrSecretInvalidKey(id)
+		}
+		keyring.keys[id] = key
+	}
+
+	return keyring, nil
+}
+
+func EncryptSecret(
+	keyring io.Reader,
+	value string,
+) (string, error) {
+	loaded, e := newKeyring(keyring)
+	if e != nil {
+		return "", e
+	}
+
+	return loaded.Encrypt(value)
+}
+
+func (keyring *keyring) Encrypt(
+	value string,
+) (string, error) {
+	gcm, e := keyring.cipher(keyring.primary)
+	if e != nil {
+		return "", e
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, e := rand.Read(nonce); e != nil {
+		return "", e
+	}
+
+	sealed := gcm.Seal(nonce, nonce, []byte(value), []byte(secretPrefix+keyring.primary))
+
+	return secretPrefix + keyring.primary + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (keyring *keyring) Decrypt(
+	value string,
+) (string, error) {
+	id, encoded, ok := strings.Cut(strings.TrimPrefix(value, secretPrefix), ":")
+	if !strings.HasPrefix(value, secretPrefix) || !ok {
+		return "", newErrSecretInvalidValue(value)
+	}
+
+	sealed, e := base64.StdEncoding.DecodeString(encoded)
+	if e != nil {
+		return "", newErrSecretInvalidValue(value)
+	}
+
+	gcm, e := keyring.cipher(id)
+	if e != nil {
+		return "", e
+	}
+
+	if len(sealed) < gcm.NonceSize() {
+		return "", newErrSecretInvalidValue(value)
+	}
+
+	plain, e := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], []byte(secretPrefix+id))
+	if e != nil {
+		return "", newErrSecretDecryption(id)
+	}
+
+	return string(plain), nil
+}
+
+func (keyring *keyring) decrypt(
+	value any,
+) (any, error) {
+	switch v := value.(type) {
+	case string:
+		if strings.HasPrefix(v, secretPrefix) {
+			return keyring.Decrypt(v)
+		}
+	case flam.Bag:
+		bag := flam.Bag{}
+		for key, item := range v {
+			decrypted, e := keyring.decrypt(item)
+			if e != nil {
+				return nil, e
+			}
+			bag[key] = decrypted
+		}
+		return bag, nil
+	case []any:
+		list := make([]any, len(v))
+		for i, item := range v {
+			decrypted, e := keyring.decrypt(item)
+			if e != nil {
+				return nil, e
+			}
+			list[i] = decrypted
+		}
+		return list, nil
+	}
+
+	return value, nil
+}
+
+func (keyring *keyring) cipher(
+	id string,
+) (cipher.AEAD, error) {
+	key, ok := keyring.keys[id]
+	if !ok {
+		return nil, newErrSecretKeyNotFound(id)
+	}
+
+	block, e := aes.NewCipher(key)
+	if e != nil {
+		return nil, e
+	}
+
+	return cipher.NewGCM(block)
+}
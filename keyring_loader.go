@@ -0,0 +1,49 @@
+package config
+
+import (
+	"os"
+
+	"go.uber.org/dig"
+
+	filesystem "github.com/happyhippyhippo/flam-filesystem"
+)
+
+type keyringLoader struct {
+	fileSystemFacade filesystem.Facade
+}
+
+type keyringLoaderArgs struct {
+	dig.In
+
+	FileSystemFacade filesystem.Facade `optional:"true"`
+}
+
+func newKeyringLoader(
+	args keyringLoaderArgs,
+) *keyringLoader {
+	return &keyringLoader{
+		fileSystemFacade: args.FileSystemFacade,
+	}
+}
+
+func (loader *keyringLoader) Load(
+	diskId string,
+	path string,
+) (*keyring, error) {
+	if loader.fileSystemFacade == nil {
+		return nil, newErrSecretKeyringDiskNotFound(path)
+	}
+
+	disk, e := loader.fileSystemFacade.GetDisk(diskId)
+	if e != nil {
+		return nil, e
+	}
+
+	file, e := disk.OpenFile(path, os.O_RDONLY, 0o644)
+	if e != nil {
+		return nil, e
+	}
+	defer func() { _ = file.Close() }()
+
+	return newKeyring(file)
+}
@@ -14,6 +14,7 @@ import (
 type regSource struct {
 	id     string
 	source Source
+	bag    flam.Bag
 }
 
 type regSourceSorter []regSource
@@ -41,6 +42,7 @@ type manager struct {
 	observers map[string]regObserver
 	aggregate flam.Bag
 	local     flam.Bag
+	keyring   *keyring
 }
 
 func newManager() *manager {
@@ -57,13 +59,20 @@ func (manager *manager) Set(
 	path string,
 	value any,
 ) error {
-	if e := manager.local.Set(path, value); e != nil {
+	manager.locker.Lock()
+	defer manager.locker.Unlock()
+
+	local := manager.local.Clone()
+	if e := local.Set(path, value); e != nil {
 		return e
 	}
 
-	manager.locker.Lock()
-	defer manager.locker.Unlock()
+	decrypted, e := manager.decrypt(manager.keyring, local)
+	if e != nil {
+		return e
+	}
 
+	manager.local = decrypted
 	manager.rebuild()
 
 	return nil
@@ -125,9 +134,14 @@ func (manager *manager) AddSource(
 	manager.locker.Lock()
 	defer manager.locker.Unlock()
 
-	manager.sources = append(manager.sources, regSource{id, source})
+	manager.sources = append(manager.sources, regSource{id: id, source: source})
 	sort.Sort(regSourceSorter(manager.sources))
-	manager.rebuild()
+	if e, ok := manager.rebuild()[id]; ok {
+		manager.sources = slices.DeleteFunc(manager.sources, func(reg regSource) bool {
+			return reg.id == id
+		})
+		return e
+	}
 
 	return nil
 }
@@ -215,7 +229,7 @@ func (manager *manager) ReloadSources() error {
 	}
 
 	if reloaded {
-		manager.rebuild()
+		return manager.rejection(manager.rebuild())
 	}
 
 	return nil
@@ -272,10 +286,67 @@ func (manager *manager) RemoveObserver(
 	return nil
 }
 
-func (manager *manager) rebuild() {
-	updated := flam.Bag{}
+func (manager *manager) SetKeyring(
+	keyring *keyring,
+) error {
+	manager.locker.Lock()
+	defer manager.locker.Unlock()
+
+	local, e := manager.decrypt(keyring, manager.local)
+	if e != nil {
+		return e
+	}
+
+	previousKeyring, previousLocal := manager.keyring, manager.local
+	manager.keyring, manager.local = keyring, local
+	if e := manager.rejection(manager.rebuild()); e != nil {
+		manager.keyring, manager.local = previousKeyring, previousLocal
+		manager.rebuild()
+		return e
+	}
+
+	return nil
+}
+
+func (manager *manager) decrypt(
+	keyring *keyring,
+	bag flam.Bag,
+) (flam.Bag, error) {
+	if keyring == nil {
+		return bag, nil
+	}
+
+	decrypted, e := keyring.decrypt(bag)
+	if e != nil {
+		return nil, e
+	}
+
+	return decrypted.(flam.Bag), nil
+}
+
+func (manager *manager) rejection(
+	rejected map[string]error,
+) error {
 	for _, ref := range manager.sources {
-		updated.Merge(ref.source.Get("", flam.Bag{}).(flam.Bag))
+		if e, ok := rejected[ref.id]; ok {
+			return e
+		}
+	}
+
+	return nil
+}
+
+func (manager *manager) rebuild() map[string]error {
+	rejected := map[string]error{}
+	updated := flam.Bag{}
+	for i, ref := range manager.sources {
+		bag, e := manager.decrypt(manager.keyring, ref.source.Get("", flam.Bag{}).(flam.Bag))
+		if e != nil {
+			rejected[ref.id] = e
+		} else {
+			manager.sources[i].bag = bag
+		}
+		updated.Merge(manager.sources[i].bag)
 	}
 
 	updated.Merge(manager.local)
@@ -296,4 +367,6 @@ func (manager *manager) rebuild() {
 			}
 		}
 	}
+
+	return rejected
 }
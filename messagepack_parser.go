@@ -0,0 +1,77 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/vmihailenco/msgpack/v5"
+
+	flam "github.com/happyhippyhippo/flam"
+)
+
+type messagePackParser struct{}
+
+func newMessagePackParser() Parser {
+	return &messagePackParser{}
+}
+
+func (parser messagePackParser) Close() error {
+	return nil
+}
+
+func (parser messagePackParser) Parse(
+	reader io.Reader,
+) (flam.Bag, error) {
+	b, e := io.ReadAll(reader)
+	if e != nil {
+		return nil, e
+	}
+
+	decoder := msgpack.NewDecoder(bytes.NewReader(b))
+	decoder.SetMapDecoder(parser.decodeMap)
+
+	value, e := decoder.DecodeInterface()
+	if e != nil {
+		return nil, e
+	}
+
+	data, ok := value.(map[any]any)
+	if !ok {
+		return nil, newErrMessagePackInvalidRoot(fmt.Sprintf("%T", value))
+	}
+
+	return Convert(data).(flam.Bag), nil
+}
+
+func (parser messagePackParser) decodeMap(
+	decoder *msgpack.Decoder,
+) (any, error) {
+	n, e := decoder.DecodeMapLen()
+	if e != nil || n == -1 {
+		return nil, e
+	}
+
+	data := make(map[any]any, n)
+	for i := 0; i < n; i++ {
+		key, e := decoder.DecodeInterface()
+		if e != nil {
+			return nil, e
+		}
+
+		if b, ok := key.([]byte); ok {
+			key = string(b)
+		} else if key != nil && !reflect.TypeOf(key).Comparable() {
+			key = fmt.Sprintf("%v", key)
+		}
+
+		value, e := decoder.DecodeInterface()
+		if e != nil {
+			return nil, e
+		}
+		data[key] = value
+	}
+
+	return data, nil
+}
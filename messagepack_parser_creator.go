@@ -0,0 +1,23 @@
+package config
+
+import (
+	flam "github.com/happyhippyhippo/flam"
+)
+
+type messagePackParserCreator struct{}
+
+func newMessagePackParserCreator() ParserCreator {
+	return &messagePackParserCreator{}
+}
+
+func (messagePackParserCreator) Accept(
+	config flam.Bag,
+) bool {
+	return config.String("driver") == ParserDriverMessagePack
+}
+
+func (messagePackParserCreator) Create(
+	_ flam.Bag,
+) (Parser, error) {
+	return newMessagePackParser(), nil
+}
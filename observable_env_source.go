@@ -0,0 +1,85 @@
+package config
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	flam "github.com/happyhippyhippo/flam"
+	filesystem "github.com/happyhippyhippo/flam-filesystem"
+)
+
+type observableEnvSource struct {
+	envSource
+
+	loaded    bool
+	timestamp time.Time
+}
+
+func newObservableEnvSource(
+	priority int,
+	files []string,
+	mappings map[string]envMapping,
+	prefix string,
+	separator string,
+	caseRule string,
+	infer bool,
+	prefixFiles bool,
+	fileSystemFacade filesystem.Facade,
+	diskId string,
+) (Source, error) {
+	if e := checkEnvSourceOptions(caseRule, mappings); e != nil {
+		return nil, e
+	}
+
+	source := &observableEnvSource{
+		envSource: envSource{
+			source: source{
+				mutex:    &sync.Mutex{},
+				bag:      flam.Bag{},
+				priority: priority,
+			},
+			files:            files,
+			mappings:         mappings,
+			prefix:           prefix,
+			separator:        separator,
+			caseRule:         caseRule,
+			infer:            infer,
+			isolated:         true,
+			prefixFiles:      prefixFiles,
+			fileSystemFacade: fileSystemFacade,
+			diskId:           diskId,
+		},
+	}
+
+	if _, e := source.Reload(); e != nil {
+		return nil, e
+	}
+
+	return source, nil
+}
+
+func (source *observableEnvSource) Reload() (bool, error) {
+	var modTime time.Time
+	for _, file := range source.files {
+		fileStats, e := os.Stat(file)
+		if e != nil {
+			return false, e
+		}
+
+		if fileStats.ModTime().After(modTime) {
+			modTime = fileStats.ModTime()
+		}
+	}
+
+	if !source.loaded || source.timestamp.Before(modTime) {
+		if e := source.load(); e != nil {
+			return false, e
+		}
+		source.loaded = true
+		source.timestamp = modTime
+
+		return true, nil
+	}
+	return false, nil
+}
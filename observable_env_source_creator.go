@@ -0,0 +1,42 @@
+package config
+
+import (
+	flam "github.com/happyhippyhippo/flam"
+)
+
+type observableEnvSourceCreator struct {
+	envSourceCreator
+}
+
+func newObservableEnvSourceCreator(
+	args envSourceCreatorArgs,
+) SourceCreator {
+	return &observableEnvSourceCreator{
+		envSourceCreator: envSourceCreator{
+			fileSystemFacade: args.FileSystemFacade,
+		},
+	}
+}
+
+func (observableEnvSourceCreator) Accept(
+	config flam.Bag,
+) bool {
+	return config.String("driver") == SourceDriverObservableEnv
+}
+
+func (creator observableEnvSourceCreator) Create(
+	config flam.Bag,
+) (Source, error) {
+	return newObservableEnvSource(
+		config.Int("priority"),
+		config.StringSlice("files", []string{}),
+		creator.mappings(config),
+		config.String("prefix"),
+		config.String("separator", DefaultEnvSeparator),
+		config.String("case", DefaultEnvCase),
+		config.Bool("infer"),
+		config.Bool("prefix_files"),
+		creator.fileSystemFacade,
+		config.String("disk", DefaultFileDisk),
+	)
+}
@@ -17,22 +17,28 @@ type observableFileSource struct {
 }
 
 func newObservableFileSource(
+	id string,
 	priority int,
 	disk filesystem.Disk,
 	path string,
 	parser Parser,
+	maxDecompressedSize int,
+	templateRenderer *templateRenderer,
 	timeFacade flamTime.Facade,
 ) (Source, error) {
 	source := &observableFileSource{
 		fileSource: fileSource{
 			source: source{
+				id:       id,
 				mutex:    &sync.Mutex{},
 				bag:      flam.Bag{},
 				priority: priority,
 			},
-			disk:   disk,
-			path:   path,
-			parser: parser,
+			disk:                disk,
+			path:                path,
+			parser:              parser,
+			maxDecompressedSize: maxDecompressedSize,
+			templateRenderer:    templateRenderer,
 		},
 		timeFacade: timeFacade,
 		timestamp:  timeFacade.Unix(0, 0),
@@ -15,12 +15,16 @@ type observableFileSourceCreator struct {
 func newObservableFileSourceCreator(
 	fileSystemFacade filesystem.Facade,
 	parserFactory parserFactory,
+	parserRegistry *parserRegistry,
+	templateRenderer *templateRenderer,
 	timeFacade time.Facade,
 ) SourceCreator {
 	return &observableFileSourceCreator{
 		fileSourceCreator: fileSourceCreator{
 			fileSystemFacade: fileSystemFacade,
 			parserFactory:    parserFactory,
+			parserRegistry:   parserRegistry,
+			templateRenderer: templateRenderer,
 		},
 		timeFacade: timeFacade,
 	}
@@ -42,16 +46,23 @@ func (creator observableFileSourceCreator) Create(
 		return nil, e
 	}
 
-	parserId := config.String("parser", DefaultFileParser)
-	parser, e := creator.parserFactory.Get(parserId)
+	var parser Parser
+	if parserId := config.String("parser"); parserId != "" {
+		parser, e = creator.parserFactory.Get(parserId)
+	} else {
+		parser, e = creator.parserRegistry.ForPath(config.String("path"))
+	}
 	if e != nil {
 		return nil, e
 	}
 
 	return newObservableFileSource(
+		config.String("id"),
 		config.Int("priority"),
 		disk,
 		config.String("path"),
 		parser,
+		config.Int("max_decompressed_size", DefaultMaxDecompressedSize),
+		creator.templateRenderer.forSource(config),
 		creator.timeFacade)
 }
@@ -17,25 +17,33 @@ type observableRestSource struct {
 }
 
 func newObservableRestSource(
+	id string,
 	priority int,
 	restRequester RestRequester,
 	uri string,
 	parser Parser,
+	parserRegistry *parserRegistry,
 	configPath string,
+	maxDecompressedSize int,
+	templateRenderer *templateRenderer,
 	timestampPath string,
 	timeFacade flamTime.Facade,
 ) (Source, error) {
 	source := &observableRestSource{
 		restSource: restSource{
 			source: source{
+				id:       id,
 				mutex:    &sync.Mutex{},
 				bag:      flam.Bag{},
 				priority: priority,
 			},
-			uri:           uri,
-			configPath:    configPath,
-			restRequester: restRequester,
-			parser:        parser,
+			uri:                 uri,
+			configPath:          configPath,
+			restRequester:       restRequester,
+			parser:              parser,
+			parserRegistry:      parserRegistry,
+			maxDecompressedSize: maxDecompressedSize,
+			templateRenderer:    templateRenderer,
 		},
 		timestampPath: timestampPath,
 		timestamp:     timeFacade.Now(),
@@ -84,6 +92,10 @@ func (source *observableRestSource) getTimestamp(
 		return time.Unix(0, 0), newErrRestTimestampNotFound(source.timestampPath, response)
 	}
 
+	if timeTimestamp, ok := timestamp.(time.Time); ok {
+		return timeTimestamp, nil
+	}
+
 	stringTimestamp, ok := timestamp.(string)
 	if !ok {
 		return time.Unix(0, 0), newErrRestInvalidTimestamp(source.timestampPath, timestamp)
@@ -8,17 +8,23 @@ import (
 type observableRestSourceCreator struct {
 	restRequesterGenerator RestRequesterGenerator
 	parserFactory          parserFactory
+	parserRegistry         *parserRegistry
+	templateRenderer       *templateRenderer
 	timeFacade             time.Facade
 }
 
 func newObservableRestSourceCreator(
 	restRequesterGenerator RestRequesterGenerator,
 	parserFactory parserFactory,
+	parserRegistry *parserRegistry,
+	templateRenderer *templateRenderer,
 	timeFacade time.Facade,
 ) SourceCreator {
 	return &observableRestSourceCreator{
 		restRequesterGenerator: restRequesterGenerator,
 		parserFactory:          parserFactory,
+		parserRegistry:         parserRegistry,
+		templateRenderer:       templateRenderer,
 		timeFacade:             timeFacade,
 	}
 }
@@ -40,18 +46,23 @@ func (creator observableRestSourceCreator) Create(
 		return nil, e
 	}
 
-	parserId := config.String("parser", DefaultRestParser)
-	parser, e := creator.parserFactory.Get(parserId)
-	if e != nil {
-		return nil, e
+	var parser Parser
+	if parserId := config.String("parser", DefaultRestParser); parserId != "" {
+		if parser, e = creator.parserFactory.Get(parserId); e != nil {
+			return nil, e
+		}
 	}
 
 	return newObservableRestSource(
+		config.String("id"),
 		config.Int("priority"),
 		requester,
 		config.String("uri"),
 		parser,
+		creator.parserRegistry,
 		config.String("path.config"),
+		config.Int("max_decompressed_size", DefaultMaxDecompressedSize),
+		creator.templateRenderer.forSource(config),
 		config.String("path.timestamp"),
 		creator.timeFacade)
 }
@@ -0,0 +1,167 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/hashicorp/hcl/v2"
+)
+
+type ParseError struct {
+	Source  string
+	Path    string
+	Line    int
+	Column  int
+	Snippet string
+	Err     error
+}
+
+func newParseError(
+	source string,
+	path string,
+	content []byte,
+	e error,
+) error {
+	var parseErr *ParseError
+	if errors.As(e, &parseErr) {
+		return e
+	}
+
+	line, column := parseErrorPosition(content, e)
+
+	return &ParseError{
+		Source:  source,
+		Path:    path,
+		Line:    line,
+		Column:  column,
+		Snippet: parseErrorSnippet(content, line),
+		Err:     e,
+	}
+}
+
+func (e *ParseError) Error() string {
+	location := e.Path
+	if e.Line > 0 {
+		location += fmt.Sprintf(":%d", e.Line)
+		if e.Column > 0 {
+			location += fmt.Sprintf(":%d", e.Column)
+		}
+	}
+
+	msg := fmt.Sprintf("%v: %s => %s => %v", ErrParse, e.Source, location, e.Err)
+	if e.Snippet != "" {
+		msg += fmt.Sprintf(" => %q", e.Snippet)
+	}
+
+	return msg
+}
+
+func (e *ParseError) Unwrap() []error {
+	return []error{ErrParse, e.Err}
+}
+
+type positionError struct {
+	line   int
+	column int
+	err    error
+}
+
+func newPositionError(
+	line int,
+	column int,
+	e error,
+) error {
+	return &positionError{
+		line:   line,
+		column: column,
+		err:    e,
+	}
+}
+
+func (e *positionError) Error() string {
+	return e.err.Error()
+}
+
+func (e *positionError) Unwrap() error {
+	return e.err
+}
+
+func parseErrorPosition(
+	content []byte,
+	e error,
+) (int, int) {
+	var positionErr *positionError
+	if errors.As(e, &positionErr) {
+		return positionErr.line, positionErr.column
+	}
+
+	var jsonSyntaxErr *json.SyntaxError
+	if errors.As(e, &jsonSyntaxErr) {
+		return parseErrorOffsetPosition(content, jsonSyntaxErr.Offset)
+	}
+
+	var jsonTypeErr *json.UnmarshalTypeError
+	if errors.As(e, &jsonTypeErr) {
+		return parseErrorOffsetPosition(content, jsonTypeErr.Offset)
+	}
+
+	var tomlErr toml.ParseError
+	if errors.As(e, &tomlErr) {
+		return tomlErr.Position.Line, tomlErr.Position.Col
+	}
+
+	var hclDiags hcl.Diagnostics
+	if errors.As(e, &hclDiags) {
+		for _, diag := range hclDiags {
+			if diag.Subject != nil {
+				return diag.Subject.Start.Line, diag.Subject.Start.Column
+			}
+		}
+	}
+
+	var xmlErr *xml.SyntaxError
+	if errors.As(e, &xmlErr) {
+		return xmlErr.Line, 0
+	}
+
+	return 0, 0
+}
+
+func parseErrorOffsetPosition(
+	content []byte,
+	offset int64,
+) (int, int) {
+	if offset > int64(len(content)) {
+		offset = int64(len(content))
+	}
+
+	before := content[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := len(before) - bytes.LastIndexByte(before, '\n') - 1
+	if column < 1 {
+		column = 1
+	}
+
+	return line, column
+}
+
+func parseErrorSnippet(
+	content []byte,
+	line int,
+) string {
+	if line <= 0 {
+		return ""
+	}
+
+	lines := strings.Split(string(content), "\n")
+	if line > len(lines) {
+		return ""
+	}
+
+	return strings.TrimRight(lines[line-1], "\r")
+}
@@ -4,6 +4,7 @@ import (
 	"io"
 
 	flam "github.com/happyhippyhippo/flam"
+	filesystem "github.com/happyhippyhippo/flam-filesystem"
 )
 
 type Parser interface {
@@ -11,3 +12,22 @@ type Parser interface {
 
 	Parse(reader io.Reader) (flam.Bag, error)
 }
+
+type DiskParser interface {
+	Parser
+
+	ParseFrom(disk filesystem.Disk, path string, reader io.Reader) (flam.Bag, error)
+}
+
+func parseFrom(
+	parser Parser,
+	disk filesystem.Disk,
+	path string,
+	reader io.Reader,
+) (flam.Bag, error) {
+	if diskParser, ok := parser.(DiskParser); ok {
+		return diskParser.ParseFrom(disk, path, reader)
+	}
+
+	return parser.Parse(reader)
+}
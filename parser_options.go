@@ -0,0 +1,172 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	flam "github.com/happyhippyhippo/flam"
+)
+
+type parserOptions struct {
+	strict                bool
+	fields                []string
+	disallowDuplicateKeys bool
+	useNumber             bool
+	maxDepth              int
+	maxSize               int
+}
+
+func newParserOptions(
+	config flam.Bag,
+	disallowDuplicateKeys bool,
+) (parserOptions, error) {
+	var fields []string
+	for _, field := range config.Slice("fields", []any{}) {
+		if str, ok := field.(string); ok {
+			fields = append(fields, strings.ToLower(str))
+		}
+	}
+	for _, field := range config.StringSlice("fields", []string{}) {
+		fields = append(fields, strings.ToLower(field))
+	}
+
+	if config.Bool("strict") && len(fields) == 0 {
+		return parserOptions{}, newErrParserStrictWithoutFields(config.String("driver"))
+	}
+
+	return parserOptions{
+		strict:                config.Bool("strict"),
+		fields:                fields,
+		disallowDuplicateKeys: config.Bool("disallow_duplicate_keys", disallowDuplicateKeys),
+		useNumber:             config.Bool("use_number"),
+		maxDepth:              config.Int("max_depth"),
+		maxSize:               config.Int("max_size"),
+	}, nil
+}
+
+func (options parserOptions) read(
+	reader io.Reader,
+) ([]byte, error) {
+	if options.maxSize <= 0 {
+		return io.ReadAll(reader)
+	}
+
+	b, e := io.ReadAll(io.LimitReader(reader, int64(options.maxSize)+1))
+	if e != nil {
+		return nil, e
+	}
+
+	if len(b) > options.maxSize {
+		return nil, newErrParserDocumentTooLarge(options.maxSize)
+	}
+
+	return b, nil
+}
+
+func (options parserOptions) check(
+	data flam.Bag,
+) error {
+	if e := options.checkDepth("", data, 1); e != nil {
+		return e
+	}
+
+	if options.strict {
+		return options.checkFields("", data)
+	}
+
+	return nil
+}
+
+func (options parserOptions) checkDepth(
+	path string,
+	value any,
+	depth int,
+) error {
+	if options.maxDepth <= 0 {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case flam.Bag:
+		if depth > options.maxDepth {
+			return newErrParserMaxDepthExceeded(path, options.maxDepth)
+		}
+
+		for _, key := range options.sortedKeys(v) {
+			if e := options.checkDepth(options.join(path, key), v[key], depth+1); e != nil {
+				return e
+			}
+		}
+	case []any:
+		if depth > options.maxDepth {
+			return newErrParserMaxDepthExceeded(path, options.maxDepth)
+		}
+
+		for i, item := range v {
+			if e := options.checkDepth(fmt.Sprintf("%s[%d]", path, i), item, depth+1); e != nil {
+				return e
+			}
+		}
+	}
+
+	return nil
+}
+
+func (options parserOptions) checkFields(
+	path string,
+	data flam.Bag,
+) error {
+	for _, key := range options.sortedKeys(data) {
+		current := options.join(path, key)
+
+		known, partial := false, false
+		for _, field := range options.fields {
+			if field == current || strings.HasPrefix(current, field+".") {
+				known = true
+				break
+			}
+			if strings.HasPrefix(field, current+".") {
+				partial = true
+			}
+		}
+
+		switch {
+		case known:
+		case partial:
+			if nested, ok := data[key].(flam.Bag); ok {
+				if e := options.checkFields(current, nested); e != nil {
+					return e
+				}
+			}
+		default:
+			return newErrParserUnknownField(current)
+		}
+	}
+
+	return nil
+}
+
+func (options parserOptions) sortedKeys(
+	data flam.Bag,
+) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (options parserOptions) join(
+	path string,
+	key string,
+) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
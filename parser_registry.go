@@ -0,0 +1,88 @@
+package config
+
+import (
+	"mime"
+	"path"
+	"strings"
+
+	flam "github.com/happyhippyhippo/flam"
+)
+
+type parserRegistry struct {
+	parserFactory parserFactory
+	manager       *manager
+}
+
+func newParserRegistry(
+	parserFactory parserFactory,
+	manager *manager,
+) *parserRegistry {
+	return &parserRegistry{
+		parserFactory: parserFactory,
+		manager:       manager,
+	}
+}
+
+func (registry *parserRegistry) ForPath(
+	filePath string,
+) (Parser, error) {
+	extension := strings.ToLower(strings.TrimPrefix(path.Ext(trimCompressionExtension(filePath)), "."))
+	mapping := registry.mapping(DefaultParserExtensions, PathParserExtensions, func(key string) string {
+		return strings.TrimPrefix(key, ".")
+	})
+
+	parserId, ok := mapping[extension]
+	if !ok || !registry.parserFactory.Has(parserId) {
+		if DefaultFileParser == "" {
+			return nil, newErrParserNotFoundForExtension(filePath)
+		}
+		parserId = DefaultFileParser
+	}
+
+	return registry.parserFactory.Get(parserId)
+}
+
+func (registry *parserRegistry) ForContentType(
+	contentType string,
+) (Parser, error) {
+	mediaType, _, e := mime.ParseMediaType(contentType)
+	if e != nil {
+		return nil, newErrParserNotFoundForContentType(contentType)
+	}
+
+	mapping := registry.mapping(DefaultParserContentTypes, PathParserContentTypes, nil)
+	if parserId, ok := mapping[mediaType]; ok {
+		return registry.parserFactory.Get(parserId)
+	}
+
+	if i := strings.LastIndex(mediaType, "+"); i != -1 {
+		if parserId, ok := mapping[mediaType[i:]]; ok {
+			return registry.parserFactory.Get(parserId)
+		}
+	}
+
+	return nil, newErrParserNotFoundForContentType(contentType)
+}
+
+func (registry *parserRegistry) mapping(
+	defaults map[string]string,
+	configPath string,
+	normalize func(key string) string,
+) map[string]string {
+	mapping := map[string]string{}
+	for key, parserId := range defaults {
+		mapping[key] = parserId
+	}
+
+	for key, value := range registry.manager.aggregate.Bag(configPath, flam.Bag{}) {
+		if normalize != nil {
+			key = normalize(key)
+		}
+
+		if parserId, ok := value.(string); ok {
+			mapping[strings.ToLower(key)] = parserId
+		}
+	}
+
+	return mapping
+}
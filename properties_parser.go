@@ -0,0 +1,160 @@
+package config
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	flam "github.com/happyhippyhippo/flam"
+)
+
+type propertiesParser struct{}
+
+func newPropertiesParser() Parser {
+	return &propertiesParser{}
+}
+
+func (parser propertiesParser) Close() error {
+	return nil
+}
+
+func (parser propertiesParser) Parse(
+	reader io.Reader,
+) (flam.Bag, error) {
+	data := flam.Bag{}
+
+	scanner := bufio.NewScanner(reader)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimLeft(scanner.Text(), " \t\f")
+		if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+
+		for parser.continues(line) && scanner.Scan() {
+			lineNumber++
+			line = line[:len(line)-1] + strings.TrimLeft(scanner.Text(), " \t\f")
+		}
+
+		rawKey, rawValue := parser.split(line)
+
+		key, e := parser.unescape(rawKey)
+		if e != nil {
+			return nil, newErrPropertiesInvalidLine(lineNumber, line)
+		}
+
+		value, e := parser.unescape(rawValue)
+		if e != nil {
+			return nil, newErrPropertiesInvalidLine(lineNumber, line)
+		}
+
+		if e := data.Set(strings.ToLower(key), value); e != nil {
+			return nil, e
+		}
+	}
+
+	if e := scanner.Err(); e != nil {
+		return nil, e
+	}
+
+	return Convert(data).(flam.Bag), nil
+}
+
+func (parser propertiesParser) continues(
+	line string,
+) bool {
+	backslashes := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		backslashes++
+	}
+
+	return backslashes%2 == 1
+}
+
+func (parser propertiesParser) split(
+	line string,
+) (string, string) {
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '=', ':':
+			return line[:i], strings.TrimLeft(line[i+1:], " \t\f")
+		case ' ', '\t', '\f':
+			value := strings.TrimLeft(line[i:], " \t\f")
+			if value != "" && (value[0] == '=' || value[0] == ':') {
+				value = strings.TrimLeft(value[1:], " \t\f")
+			}
+
+			return line[:i], value
+		}
+	}
+
+	return line, ""
+}
+
+func (parser propertiesParser) unescape(
+	raw string,
+) (string, error) {
+	var builder strings.Builder
+	for i := 0; i < len(raw); i++ {
+		if raw[i] != '\\' {
+			builder.WriteByte(raw[i])
+			continue
+		}
+
+		i++
+		if i == len(raw) {
+			return "", io.ErrUnexpectedEOF
+		}
+
+		switch raw[i] {
+		case 'n':
+			builder.WriteByte('\n')
+		case 'r':
+			builder.WriteByte('\r')
+		case 't':
+			builder.WriteByte('\t')
+		case 'f':
+			builder.WriteByte('\f')
+		case 'u':
+			r, e := parser.codepoint(raw, i+1)
+			if e != nil {
+				return "", e
+			}
+			i += 4
+
+			if utf16.IsSurrogate(r) && strings.HasPrefix(raw[i+1:], "\\u") {
+				if low, e := parser.codepoint(raw, i+3); e == nil {
+					r = utf16.DecodeRune(r, low)
+					i += 6
+				}
+			}
+
+			builder.WriteRune(r)
+		default:
+			builder.WriteByte(raw[i])
+		}
+	}
+
+	return builder.String(), nil
+}
+
+func (parser propertiesParser) codepoint(
+	raw string,
+	start int,
+) (rune, error) {
+	if start+4 > len(raw) {
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	code, e := strconv.ParseUint(raw[start:start+4], 16, 16)
+	if e != nil {
+		return 0, e
+	}
+
+	return rune(code), nil
+}
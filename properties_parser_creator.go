@@ -0,0 +1,23 @@
+package config
+
+import (
+	flam "github.com/happyhippyhippo/flam"
+)
+
+type propertiesParserCreator struct{}
+
+func newPropertiesParserCreator() ParserCreator {
+	return &propertiesParserCreator{}
+}
+
+func (propertiesParserCreator) Accept(
+	config flam.Bag,
+) bool {
+	return config.String("driver") == ParserDriverProperties
+}
+
+func (propertiesParserCreator) Create(
+	_ flam.Bag,
+) (Parser, error) {
+	return newPropertiesParser(), nil
+}
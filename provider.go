@@ -36,10 +36,33 @@ func (*provider) Register(
 	}
 
 	_ = provide(newRestRequesterGenerator) &&
+		provide(newYamlEnvTagResolver, dig.Group(YamlTagResolverGroup)) &&
+		provide(newYamlFileTagResolver, dig.Group(YamlTagResolverGroup)) &&
+		provide(newYamlIncludeTagResolver, dig.Group(YamlTagResolverGroup)) &&
+		provide(newYamlBase64TagResolver, dig.Group(YamlTagResolverGroup)) &&
 		provide(newJsonParserCreator, dig.Group(ParserCreatorGroup)) &&
 		provide(newYamlParserCreator, dig.Group(ParserCreatorGroup)) &&
+		provide(newTomlParserCreator, dig.Group(ParserCreatorGroup)) &&
+		provide(newIniParserCreator, dig.Group(ParserCreatorGroup)) &&
+		provide(newPropertiesParserCreator, dig.Group(ParserCreatorGroup)) &&
+		provide(newHclParserCreator, dig.Group(ParserCreatorGroup)) &&
+		provide(newXmlParserCreator, dig.Group(ParserCreatorGroup)) &&
+		provide(newJson5ParserCreator, dig.Group(ParserCreatorGroup)) &&
+		provide(newHoconParserCreator, dig.Group(ParserCreatorGroup)) &&
+		provide(newJsonnetParserCreator, dig.Group(ParserCreatorGroup)) &&
+		provide(newCueParserCreator, dig.Group(ParserCreatorGroup)) &&
+		provide(newStarlarkParserCreator, dig.Group(ParserCreatorGroup)) &&
+		provide(newMessagePackParserCreator, dig.Group(ParserCreatorGroup)) &&
+		provide(newCborParserCreator, dig.Group(ParserCreatorGroup)) &&
+		provide(newDotenvParserCreator, dig.Group(ParserCreatorGroup)) &&
+		provide(newSopsParserCreator, dig.Group(ParserCreatorGroup)) &&
 		provide(newParserFactory) &&
+		provide(newParserRegistry) &&
+		provide(newTemplateRenderer) &&
+		provide(newKeyringLoader) &&
 		provide(newEnvSourceCreator, dig.Group(SourceCreatorGroup)) &&
+		provide(newObservableEnvSourceCreator, dig.Group(SourceCreatorGroup)) &&
+		provide(newFlagsSourceCreator, dig.Group(SourceCreatorGroup)) &&
 		provide(newFileSourceCreator, dig.Group(SourceCreatorGroup)) &&
 		provide(newObservableFileSourceCreator, dig.Group(SourceCreatorGroup)) &&
 		provide(newDirSourceCreator, dig.Group(SourceCreatorGroup)) &&
@@ -63,6 +86,7 @@ func (provider *provider) Boot(
 	return container.Invoke(func(
 		manager *manager,
 		sourceFactory sourceFactory,
+		keyringLoader *keyringLoader,
 	) error {
 		defaultsSource := &source{mutex: &sync.Mutex{}, bag: Defaults, priority: -1}
 		if e := manager.AddSource("defaults", defaultsSource); e != nil {
@@ -72,6 +96,18 @@ func (provider *provider) Boot(
 		DefaultFileParser = manager.aggregate.String(PathDefaultFileParser, DefaultFileParser)
 		DefaultFileDisk = manager.aggregate.String(PathDefaultFileDisk, DefaultFileDisk)
 		DefaultRestParser = manager.aggregate.String(PathDefaultRestParser, DefaultRestParser)
+		DefaultMaxDecompressedSize = manager.aggregate.Int(PathDefaultMaxDecompressedSize, DefaultMaxDecompressedSize)
+
+		if keyringPath := manager.aggregate.String(PathSecretsKeyring); keyringPath != "" {
+			keyring, e := keyringLoader.Load(manager.aggregate.String(PathSecretsDisk, DefaultFileDisk), keyringPath)
+			if e != nil {
+				return e
+			}
+
+			if e := manager.SetKeyring(keyring); e != nil {
+				return e
+			}
+		}
 
 		if manager.aggregate.Bool(PathBoot) {
 			for id := range manager.aggregate.Bag(PathSources) {
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"bytes"
+	"io"
 	"net/http"
 	"sync"
 
@@ -10,29 +12,40 @@ import (
 type restSource struct {
 	source
 
-	restRequester RestRequester
-	uri           string
-	parser        Parser
-	configPath    string
+	restRequester       RestRequester
+	uri                 string
+	parser              Parser
+	parserRegistry      *parserRegistry
+	configPath          string
+	maxDecompressedSize int
+	templateRenderer    *templateRenderer
 }
 
 func newRestSource(
+	id string,
 	priority int,
 	restRequester RestRequester,
 	uri string,
 	parser Parser,
+	parserRegistry *parserRegistry,
 	configPath string,
+	maxDecompressedSize int,
+	templateRenderer *templateRenderer,
 ) (Source, error) {
 	source := &restSource{
 		source: source{
+			id:       id,
 			mutex:    &sync.Mutex{},
 			bag:      flam.Bag{},
 			priority: priority,
 		},
-		restRequester: restRequester,
-		uri:           uri,
-		parser:        parser,
-		configPath:    configPath,
+		restRequester:       restRequester,
+		uri:                 uri,
+		parser:              parser,
+		parserRegistry:      parserRegistry,
+		configPath:          configPath,
+		maxDecompressedSize: maxDecompressedSize,
+		templateRenderer:    templateRenderer,
 	}
 
 	if e := source.load(); e != nil {
@@ -71,7 +84,34 @@ func (source *restSource) request() (flam.Bag, error) {
 		return nil, e
 	}
 
-	return source.parser.Parse(response.Body)
+	parser := source.parser
+	if parser == nil {
+		contentType := response.Header.Get("Content-Type")
+		if parser, e = source.parserRegistry.ForContentType(contentType); e != nil {
+			return nil, e
+		}
+	}
+
+	reader, e := decompressResponse(response.Body, response.Header.Get("Content-Encoding"), source.maxDecompressedSize)
+	if e != nil {
+		return nil, e
+	}
+	defer func() { _ = reader.Close() }()
+
+	var rendered io.Reader = reader
+	if source.templateRenderer != nil {
+		if rendered, e = source.templateRenderer.Render(source.id, source.uri, reader); e != nil {
+			return nil, e
+		}
+	}
+
+	content := &bytes.Buffer{}
+	bag, e := parser.Parse(io.TeeReader(rendered, content))
+	if e != nil {
+		return nil, newParseError(source.id, source.uri, content.Bytes(), e)
+	}
+
+	return bag, nil
 }
 
 func (source *restSource) getConfig(
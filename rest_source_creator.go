@@ -7,15 +7,21 @@ import (
 type restSourceCreator struct {
 	restRequesterGenerator RestRequesterGenerator
 	parserFactory          parserFactory
+	parserRegistry         *parserRegistry
+	templateRenderer       *templateRenderer
 }
 
 func newRestSourceCreator(
 	restRequesterGenerator RestRequesterGenerator,
 	parserFactory parserFactory,
+	parserRegistry *parserRegistry,
+	templateRenderer *templateRenderer,
 ) SourceCreator {
 	return &restSourceCreator{
 		restRequesterGenerator: restRequesterGenerator,
 		parserFactory:          parserFactory,
+		parserRegistry:         parserRegistry,
+		templateRenderer:       templateRenderer,
 	}
 }
 
@@ -35,16 +41,21 @@ func (creator restSourceCreator) Create(
 		return nil, e
 	}
 
-	parserId := config.String("parser", DefaultRestParser)
-	parser, e := creator.parserFactory.Get(parserId)
-	if e != nil {
-		return nil, e
+	var parser Parser
+	if parserId := config.String("parser", DefaultRestParser); parserId != "" {
+		if parser, e = creator.parserFactory.Get(parserId); e != nil {
+			return nil, e
+		}
 	}
 
 	return newRestSource(
+		config.String("id"),
 		config.Int("priority"),
 		requester,
 		config.String("uri"),
 		parser,
-		config.String("path.config"))
+		creator.parserRegistry,
+		config.String("path.config"),
+		config.Int("max_decompressed_size", DefaultMaxDecompressedSize),
+		creator.templateRenderer.forSource(config))
 }
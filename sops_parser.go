@@ -0,0 +1,286 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"gopkg.in/yaml.v3"
+
+	flam "github.com/happyhippyhippo/flam"
+)
+
+var sopsValueRegexp = regexp.MustCompile(`^ENC\[AES256_GCM,data:(.+),iv:(.+),tag:(.+),type:(.+)\]$`)
+
+type sopsMetadata struct {
+	Age []struct {
+		Recipient string `yaml:"recipient"`
+		Enc       string `yaml:"enc"`
+	} `yaml:"age"`
+	LastModified     string `yaml:"lastmodified"`
+	Mac              string `yaml:"mac"`
+	MacOnlyEncrypted bool   `yaml:"mac_only_encrypted"`
+}
+
+type sopsParser struct {
+	identities []age.Identity
+}
+
+func newSopsParser(
+	identities []age.Identity,
+) Parser {
+	return &sopsParser{
+		identities: identities,
+	}
+}
+
+func (parser *sopsParser) Close() error {
+	return nil
+}
+
+func (parser *sopsParser) Parse(
+	reader io.Reader,
+) (flam.Bag, error) {
+	b, e := io.ReadAll(reader)
+	if e != nil {
+		return nil, e
+	}
+
+	document := yaml.Node{}
+	if e := yaml.Unmarshal(b, &document); e != nil {
+		return nil, e
+	}
+	if len(document.Content) == 0 {
+		return flam.Bag{}, nil
+	}
+
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, newErrSopsInvalidDocument(root.Tag)
+	}
+
+	var metadataNode *yaml.Node
+	tree := &yaml.Node{Kind: yaml.MappingNode}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "sops" {
+			metadataNode = root.Content[i+1]
+			continue
+		}
+		tree.Content = append(tree.Content, root.Content[i], root.Content[i+1])
+	}
+
+	if metadataNode == nil {
+		data := map[string]any{}
+		if e := tree.Decode(&data); e != nil {
+			return nil, e
+		}
+
+		return Convert(data).(flam.Bag), nil
+	}
+
+	metadata := sopsMetadata{}
+	if e := metadataNode.Decode(&metadata); e != nil {
+		return nil, e
+	}
+
+	key, e := parser.dataKey(metadata)
+	if e != nil {
+		return nil, e
+	}
+
+	mac := sha512.New()
+	data, e := parser.decrypt(key, tree, nil, mac, metadata.MacOnlyEncrypted)
+	if e != nil {
+		return nil, e
+	}
+
+	if e := parser.verify(key, metadata, mac); e != nil {
+		return nil, e
+	}
+
+	return Convert(data).(flam.Bag), nil
+}
+
+func (parser *sopsParser) dataKey(
+	metadata sopsMetadata,
+) ([]byte, error) {
+	var recipients []string
+	for _, entry := range metadata.Age {
+		recipients = append(recipients, entry.Recipient)
+
+		reader, e := age.Decrypt(armor.NewReader(strings.NewReader(entry.Enc)), parser.identities...)
+		if e != nil {
+			continue
+		}
+
+		return io.ReadAll(reader)
+	}
+
+	return nil, newErrSopsDataKeyNotFound(strings.Join(recipients, ", "))
+}
+
+func (parser *sopsParser) verify(
+	key []byte,
+	metadata sopsMetadata,
+	mac hash.Hash,
+) error {
+	lastModified, e := time.Parse(time.RFC3339, metadata.LastModified)
+	if e != nil {
+		return e
+	}
+
+	expected, e := parser.decryptValue(key, metadata.Mac, lastModified.Format(time.RFC3339))
+	if e != nil {
+		return e
+	}
+
+	computed := fmt.Sprintf("%X", mac.Sum(nil))
+	if value, ok := expected.(string); !ok || subtle.ConstantTimeCompare([]byte(value), []byte(computed)) != 1 {
+		return newErrSopsMacMismatch(metadata.LastModified)
+	}
+
+	return nil
+}
+
+func (parser *sopsParser) decrypt(
+	key []byte,
+	node *yaml.Node,
+	path []string,
+	mac hash.Hash,
+	macOnlyEncrypted bool,
+) (any, error) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		data := map[string]any{}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			name := node.Content[i].Value
+			value, e := parser.decrypt(key, node.Content[i+1], append(path[:len(path):len(path)], name), mac, macOnlyEncrypted)
+			if e != nil {
+				return nil, e
+			}
+			data[name] = value
+		}
+		return data, nil
+	case yaml.SequenceNode:
+		list := []any{}
+		for _, item := range node.Content {
+			value, e := parser.decrypt(key, item, path, mac, macOnlyEncrypted)
+			if e != nil {
+				return nil, e
+			}
+			list = append(list, value)
+		}
+		return list, nil
+	case yaml.AliasNode:
+		return parser.decrypt(key, node.Alias, path, mac, macOnlyEncrypted)
+	}
+
+	var value any
+	if e := node.Decode(&value); e != nil {
+		return nil, e
+	}
+
+	encrypted := false
+	if str, ok := value.(string); ok && sopsValueRegexp.MatchString(str) {
+		decrypted, e := parser.decryptValue(key, str, strings.Join(path, ":")+":")
+		if e != nil {
+			return nil, e
+		}
+		value = decrypted
+		encrypted = true
+	}
+
+	if encrypted || !macOnlyEncrypted {
+		mac.Write(parser.bytes(value))
+	}
+
+	return value, nil
+}
+
+func (parser *sopsParser) decryptValue(
+	key []byte,
+	value string,
+	additionalData string,
+) (any, error) {
+	match := sopsValueRegexp.FindStringSubmatch(value)
+	if match == nil {
+		return nil, newErrSopsInvalidValue(value)
+	}
+
+	data, e := base64.StdEncoding.DecodeString(match[1])
+	if e != nil {
+		return nil, e
+	}
+	iv, e := base64.StdEncoding.DecodeString(match[2])
+	if e != nil {
+		return nil, e
+	}
+	tag, e := base64.StdEncoding.DecodeString(match[3])
+	if e != nil {
+		return nil, e
+	}
+
+	block, e := aes.NewCipher(key)
+	if e != nil {
+		return nil, e
+	}
+	gcm, e := cipher.NewGCMWithNonceSize(block, len(iv))
+	if e != nil {
+		return nil, e
+	}
+
+	plain, e := gcm.Open(nil, iv, append(data, tag...), []byte(additionalData))
+	if e != nil {
+		return nil, newErrSopsDecryption(additionalData)
+	}
+
+	switch match[4] {
+	case "str":
+		return string(plain), nil
+	case "int":
+		return strconv.Atoi(string(plain))
+	case "float":
+		return strconv.ParseFloat(string(plain), 64)
+	case "bool":
+		return strconv.ParseBool(string(plain))
+	case "bytes":
+		return plain, nil
+	}
+
+	return nil, newErrSopsInvalidValue(value)
+}
+
+func (parser *sopsParser) bytes(
+	value any,
+) []byte {
+	switch v := value.(type) {
+	case string:
+		return []byte(v)
+	case []byte:
+		return v
+	case int:
+		return []byte(strconv.Itoa(v))
+	case float64:
+		return []byte(strconv.FormatFloat(v, 'f', -1, 64))
+	case bool:
+		if v {
+			return []byte("True")
+		}
+		return []byte("False")
+	case nil:
+		return nil
+	}
+
+	return []byte(fmt.Sprintf("%v", value))
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"bytes"
+	"io"
+	"os"
+
+	"filippo.io/age"
+	"go.uber.org/dig"
+
+	flam "github.com/happyhippyhippo/flam"
+	filesystem "github.com/happyhippyhippo/flam-filesystem"
+)
+
+type sopsParserCreator struct {
+	fileSystemFacade filesystem.Facade
+}
+
+type sopsParserCreatorArgs struct {
+	dig.In
+
+	FileSystemFacade filesystem.Facade `optional:"true"`
+}
+
+func newSopsParserCreator(
+	args sopsParserCreatorArgs,
+) ParserCreator {
+	return &sopsParserCreator{
+		fileSystemFacade: args.FileSystemFacade,
+	}
+}
+
+func (sopsParserCreator) Accept(
+	config flam.Bag,
+) bool {
+	return config.String("driver") == ParserDriverSops
+}
+
+func (creator sopsParserCreator) Create(
+	config flam.Bag,
+) (Parser, error) {
+	var identities []age.Identity
+
+	if keys := os.Getenv(config.String("key_env", DefaultSopsAgeKeyEnv)); keys != "" {
+		parsed, e := age.ParseIdentities(bytes.NewReader([]byte(keys)))
+		if e != nil {
+			return nil, e
+		}
+		identities = append(identities, parsed...)
+	}
+
+	if keyFile := config.String("key_file"); keyFile != "" {
+		parsed, e := creator.readKeyFile(config.String("disk", DefaultFileDisk), keyFile)
+		if e != nil {
+			return nil, e
+		}
+		identities = append(identities, parsed...)
+	}
+
+	return newSopsParser(identities), nil
+}
+
+func (creator sopsParserCreator) readKeyFile(
+	diskId string,
+	keyFile string,
+) ([]age.Identity, error) {
+	if creator.fileSystemFacade == nil {
+		return nil, newErrSopsKeyDiskNotFound(keyFile)
+	}
+
+	disk, e := creator.fileSystemFacade.GetDisk(diskId)
+	if e != nil {
+		return nil, e
+	}
+
+	file, e := disk.OpenFile(keyFile, os.O_RDONLY, 0o644)
+	if e != nil {
+		return nil, e
+	}
+	defer func() { _ = file.Close() }()
+
+	b, e := io.ReadAll(file)
+	if e != nil {
+		return nil, e
+	}
+
+	return age.ParseIdentities(bytes.NewReader(b))
+}
@@ -22,6 +22,7 @@ type ObservableSource interface {
 }
 
 type source struct {
+	id       string
 	mutex    sync.Locker
 	bag      flam.Bag
 	priority int
@@ -0,0 +1,232 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/syntax"
+
+	flam "github.com/happyhippyhippo/flam"
+	filesystem "github.com/happyhippyhippo/flam-filesystem"
+)
+
+type starlarkParser struct {
+	maxSteps uint64
+}
+
+func newStarlarkParser(
+	maxSteps uint64,
+) Parser {
+	return &starlarkParser{
+		maxSteps: maxSteps,
+	}
+}
+
+func (parser *starlarkParser) Close() error {
+	return nil
+}
+
+func (parser *starlarkParser) Parse(
+	reader io.Reader,
+) (flam.Bag, error) {
+	return parser.ParseFrom(nil, "", reader)
+}
+
+func (parser *starlarkParser) ParseFrom(
+	disk filesystem.Disk,
+	filePath string,
+	reader io.Reader,
+) (flam.Bag, error) {
+	b, e := io.ReadAll(reader)
+	if e != nil {
+		return nil, e
+	}
+
+	loader := newStarlarkLoader(disk, parser.maxSteps)
+	globals, e := loader.exec(filePath, b)
+	if e != nil {
+		return nil, e
+	}
+
+	if value, ok := globals["config"]; ok {
+		if dict, ok := value.(*starlark.Dict); ok {
+			data, e := parser.convert("config", dict)
+			if e != nil {
+				return nil, e
+			}
+
+			return Convert(data).(flam.Bag), nil
+		}
+	}
+
+	data := map[string]any{}
+	for _, name := range globals.Keys() {
+		value := globals[name]
+		if strings.HasPrefix(name, "_") {
+			continue
+		}
+		if _, ok := value.(starlark.Callable); ok {
+			continue
+		}
+
+		converted, e := parser.convert(name, value)
+		if e != nil {
+			return nil, e
+		}
+		data[name] = converted
+	}
+
+	return Convert(data).(flam.Bag), nil
+}
+
+func (parser *starlarkParser) convert(
+	path string,
+	value starlark.Value,
+) (any, error) {
+	switch v := value.(type) {
+	case starlark.NoneType:
+		return nil, nil
+	case starlark.Bool:
+		return bool(v), nil
+	case starlark.Int:
+		if i, ok := v.Int64(); ok {
+			return i, nil
+		}
+		return nil, newErrStarlarkInvalidValue(path, v.Type())
+	case starlark.Float:
+		return float64(v), nil
+	case starlark.String:
+		return string(v), nil
+	case *starlark.List:
+		return parser.convertIterable(path, v)
+	case starlark.Tuple:
+		return parser.convertIterable(path, v)
+	case *starlark.Dict:
+		data := map[string]any{}
+		for _, item := range v.Items() {
+			key, ok := item[0].(starlark.String)
+			if !ok {
+				return nil, newErrStarlarkInvalidValue(path, "non-string key "+item[0].Type())
+			}
+
+			converted, e := parser.convert(path+"."+string(key), item[1])
+			if e != nil {
+				return nil, e
+			}
+			data[string(key)] = converted
+		}
+		return data, nil
+	}
+
+	return nil, newErrStarlarkInvalidValue(path, value.Type())
+}
+
+func (parser *starlarkParser) convertIterable(
+	path string,
+	value starlark.Indexable,
+) ([]any, error) {
+	var list []any
+	for i := 0; i < value.Len(); i++ {
+		converted, e := parser.convert(fmt.Sprintf("%s[%d]", path, i), value.Index(i))
+		if e != nil {
+			return nil, e
+		}
+		list = append(list, converted)
+	}
+
+	return list, nil
+}
+
+type starlarkModule struct {
+	globals starlark.StringDict
+	err     error
+}
+
+type starlarkLoader struct {
+	disk     filesystem.Disk
+	maxSteps uint64
+	modules  map[string]*starlarkModule
+}
+
+func newStarlarkLoader(
+	disk filesystem.Disk,
+	maxSteps uint64,
+) *starlarkLoader {
+	return &starlarkLoader{
+		disk:     disk,
+		maxSteps: maxSteps,
+		modules:  map[string]*starlarkModule{},
+	}
+}
+
+func (loader *starlarkLoader) exec(
+	filePath string,
+	src []byte,
+) (starlark.StringDict, error) {
+	thread := &starlark.Thread{
+		Name:  filePath,
+		Print: func(*starlark.Thread, string) {},
+		Load:  loader.load,
+	}
+	thread.SetMaxExecutionSteps(loader.maxSteps)
+
+	options := &syntax.FileOptions{
+		Set:             true,
+		While:           true,
+		TopLevelControl: true,
+		GlobalReassign:  true,
+	}
+
+	globals, e := starlark.ExecFileOptions(options, thread, filePath, src, nil)
+	if e != nil {
+		var evalErr *starlark.EvalError
+		if errors.As(e, &evalErr) {
+			return nil, newErrStarlarkExecution(evalErr.Backtrace())
+		}
+		return nil, newErrStarlarkExecution(e.Error())
+	}
+
+	return globals, nil
+}
+
+func (loader *starlarkLoader) load(
+	thread *starlark.Thread,
+	module string,
+) (starlark.StringDict, error) {
+	if loader.disk == nil {
+		return nil, newErrStarlarkDiskNotFound(module)
+	}
+
+	resolved := module
+	if !path.IsAbs(resolved) {
+		resolved = path.Join(path.Dir(thread.Name), module)
+	}
+
+	if cached, ok := loader.modules[resolved]; ok {
+		if cached == nil {
+			return nil, newErrStarlarkLoadCycle(resolved)
+		}
+		return cached.globals, cached.err
+	}
+
+	file, e := loader.disk.OpenFile(resolved, os.O_RDONLY, 0o644)
+	if e != nil {
+		return nil, e
+	}
+	b, e := io.ReadAll(file)
+	_ = file.Close()
+	if e != nil {
+		return nil, e
+	}
+
+	loader.modules[resolved] = nil
+	globals, e := loader.exec(resolved, b)
+	loader.modules[resolved] = &starlarkModule{globals: globals, err: e}
+
+	return globals, e
+}
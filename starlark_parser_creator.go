@@ -0,0 +1,24 @@
+package config
+
+import (
+	flam "github.com/happyhippyhippo/flam"
+)
+
+type starlarkParserCreator struct{}
+
+func newStarlarkParserCreator() ParserCreator {
+	return &starlarkParserCreator{}
+}
+
+func (starlarkParserCreator) Accept(
+	config flam.Bag,
+) bool {
+	return config.String("driver") == ParserDriverStarlark
+}
+
+func (starlarkParserCreator) Create(
+	config flam.Bag,
+) (Parser, error) {
+	return newStarlarkParser(
+		uint64(config.Int("max_steps", DefaultStarlarkMaxSteps))), nil
+}
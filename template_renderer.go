@@ -0,0 +1,123 @@
+package config
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"text/template"
+
+	flam "github.com/happyhippyhippo/flam"
+)
+
+type templateRenderer struct {
+	manager *manager
+}
+
+func newTemplateRenderer(
+	manager *manager,
+) *templateRenderer {
+	return &templateRenderer{
+		manager: manager,
+	}
+}
+
+func (renderer *templateRenderer) Render(
+	source string,
+	name string,
+	reader io.Reader,
+) (io.Reader, error) {
+	content, e := io.ReadAll(reader)
+	if e != nil {
+		return nil, e
+	}
+
+	tmpl, e := template.New(name).Funcs(renderer.funcs()).Parse(string(content))
+	if e != nil {
+		return nil, newParseError(source, name, content, newErrTemplateRender(e.Error()))
+	}
+
+	rendered := &bytes.Buffer{}
+	if e := tmpl.Execute(rendered, renderer.data()); e != nil {
+		return nil, newParseError(source, name, content, newErrTemplateRender(e.Error()))
+	}
+
+	return rendered, nil
+}
+
+func (renderer *templateRenderer) data() map[string]any {
+	env := map[string]string{}
+	for _, entry := range os.Environ() {
+		if key, value, ok := strings.Cut(entry, "="); ok {
+			env[key] = value
+		}
+	}
+
+	hostname, _ := os.Hostname()
+
+	return map[string]any{
+		"Env":      env,
+		"Hostname": hostname,
+		"Config":   renderer.manager.aggregate,
+	}
+}
+
+func (renderer *templateRenderer) funcs() template.FuncMap {
+	return template.FuncMap{
+		"default": func(def any, value any) any {
+			if templateEmpty(value) {
+				return def
+			}
+			return value
+		},
+		"required": func(msg string, value any) (any, error) {
+			if templateEmpty(value) {
+				return nil, newErrTemplateRequiredValue(msg)
+			}
+			return value, nil
+		},
+		"toJson": func(value any) (string, error) {
+			b, e := json.Marshal(value)
+			if e != nil {
+				return "", e
+			}
+			return string(b), nil
+		},
+		"b64dec": func(value string) (string, error) {
+			b, e := base64.StdEncoding.DecodeString(value)
+			if e != nil {
+				return "", e
+			}
+			return string(b), nil
+		},
+	}
+}
+
+func templateEmpty(
+	value any,
+) bool {
+	if value == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	}
+
+	return v.IsZero()
+}
+
+func (renderer *templateRenderer) forSource(
+	config flam.Bag,
+) *templateRenderer {
+	if !config.Bool("template") {
+		return nil
+	}
+
+	return renderer
+}
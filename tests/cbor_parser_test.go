@@ -0,0 +1,103 @@
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/dig"
+
+	flam "github.com/happyhippyhippo/flam"
+	config "github.com/happyhippyhippo/flam-config"
+	mocks "github.com/happyhippyhippo/flam-config/tests/mocks"
+	filesystem "github.com/happyhippyhippo/flam-filesystem"
+	flamTime "github.com/happyhippyhippo/flam-time"
+)
+
+func Test_cborParser(t *testing.T) {
+	config.Defaults = flam.Bag{}
+	_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+		"my_parser": flam.Bag{
+			"driver": config.ParserDriverCbor,
+		}})
+	defer func() { config.Defaults = flam.Bag{} }()
+
+	container := dig.New()
+	require.NoError(t, flamTime.NewProvider().Register(container))
+	require.NoError(t, filesystem.NewProvider().Register(container))
+	require.NoError(t, config.NewProvider().Register(container))
+	require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+	t.Run("should return reader error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expectedErr := errors.New("reader error")
+		reader := mocks.NewReadCloser(ctrl)
+		reader.EXPECT().Read(gomock.Any()).Return(0, expectedErr).Times(1)
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(reader)
+			assert.Nil(t, got)
+			assert.ErrorIs(t, e, expectedErr)
+		}))
+	})
+
+	t.Run("should return decoding error", func(t *testing.T) {
+		data, e := cbor.Marshal([]any{1, 2})
+		require.NoError(t, e)
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(bytes.NewReader(data))
+			assert.Nil(t, got)
+			assert.Error(t, e)
+		}))
+	})
+
+	t.Run("should decode the payload", func(t *testing.T) {
+		timestamp := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		encoder, e := cbor.EncOptions{Time: cbor.TimeRFC3339, TimeTag: cbor.EncTagRequired}.EncMode()
+		require.NoError(t, e)
+
+		data, e := encoder.Marshal(map[any]any{
+			cbor.ByteString("Binary"): "value",
+			7:                         "numeric key",
+			"Payload":                 []byte{0x01, 0x02},
+			"node": map[string]any{
+				"Updated": timestamp,
+				"count":   uint8(3),
+				"ratio":   0.5,
+				"list":    []any{-1, "a", nil, true},
+			},
+		})
+		require.NoError(t, e)
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(bytes.NewReader(data))
+			require.NoError(t, e)
+
+			assert.Equal(t, "value", got.Get("binary"))
+			assert.Equal(t, "numeric key", got.Get("7"))
+			assert.Equal(t, []byte{0x01, 0x02}, got.Get("payload"))
+			assert.Equal(t, 3, got.Get("node.count"))
+			assert.Equal(t, 0.5, got.Get("node.ratio"))
+			assert.Equal(t, []any{-1, "a", nil, true}, got.Get("node.list"))
+			assert.True(t, timestamp.Equal(got.Get("node.updated").(time.Time)))
+		}))
+	})
+}
@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -9,6 +10,8 @@ import (
 	config "github.com/happyhippyhippo/flam-config"
 )
 
+type binaryKey string
+
 func Test_Convert(t *testing.T) {
 	scenarios := []struct {
 		name string
@@ -40,6 +43,11 @@ func Test_Convert(t *testing.T) {
 			val:  map[any]any{123: "value"},
 			want: flam.Bag{"123": "value"},
 		},
+		{
+			name: "map[any]any with string kind key",
+			val:  map[any]any{binaryKey("KEY"): "value"},
+			want: flam.Bag{"key": "value"},
+		},
 		{
 			name: "float64 convertible to int",
 			val:  123.0,
@@ -50,6 +58,41 @@ func Test_Convert(t *testing.T) {
 			val:  123.4,
 			want: 123.4,
 		},
+		{
+			name: "slice of map[string]any",
+			val:  []map[string]any{{"KEY": "value"}},
+			want: []any{flam.Bag{"key": "value"}},
+		},
+		{
+			name: "int64 convertible to int",
+			val:  int64(123),
+			want: 123,
+		},
+		{
+			name: "uint64 convertible to int",
+			val:  uint64(123),
+			want: 123,
+		},
+		{
+			name: "uint64 not convertible to int",
+			val:  uint64(math.MaxUint64),
+			want: uint64(math.MaxUint64),
+		},
+		{
+			name: "sized integers",
+			val:  []any{int8(-1), int16(-2), int32(-3), uint8(1), uint16(2), uint32(3)},
+			want: []any{-1, -2, -3, 1, 2, 3},
+		},
+		{
+			name: "float32",
+			val:  []any{float32(2), float32(0.5)},
+			want: []any{2, 0.5},
+		},
+		{
+			name: "byte slice",
+			val:  []byte("value"),
+			want: []byte("value"),
+		},
 		{
 			name: "other primitive types",
 			val:  "a string",
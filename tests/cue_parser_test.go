@@ -0,0 +1,274 @@
+package tests
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/dig"
+
+	flam "github.com/happyhippyhippo/flam"
+	config "github.com/happyhippyhippo/flam-config"
+	mocks "github.com/happyhippyhippo/flam-config/tests/mocks"
+	filesystem "github.com/happyhippyhippo/flam-filesystem"
+	flamTime "github.com/happyhippyhippo/flam-time"
+)
+
+func Test_cueParser(t *testing.T) {
+	t.Run("should return reader error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverCue,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		expectedErr := errors.New("reader error")
+		reader := mocks.NewReadCloser(ctrl)
+		reader.EXPECT().Read(gomock.Any()).Return(0, expectedErr).Times(1)
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(reader)
+			assert.Nil(t, got)
+			assert.ErrorIs(t, e, expectedErr)
+		}))
+	})
+
+	t.Run("should return evaluation errors", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverCue,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		scenarios := []struct {
+			test     string
+			data     string
+			expected string
+		}{
+			{
+				test:     "syntax",
+				data:     "field: {",
+				expected: "expected '}'",
+			},
+			{
+				test:     "conflict",
+				data:     "port: int & >1024\nport: 80",
+				expected: "port: invalid value 80",
+			},
+			{
+				test:     "incomplete",
+				data:     "port: int",
+				expected: "port: incomplete value int",
+			},
+		}
+
+		for _, scenario := range scenarios {
+			t.Run(scenario.test, func(t *testing.T) {
+				assert.NoError(t, container.Invoke(func(facade config.Facade) {
+					parser, e := facade.GetParser("my_parser")
+					require.NoError(t, e)
+
+					got, e := parser.Parse(strings.NewReader(scenario.data))
+					assert.Nil(t, got)
+					assert.ErrorIs(t, e, config.ErrCueEvaluation)
+					assert.ErrorContains(t, e, scenario.expected)
+				}))
+			})
+		}
+	})
+
+	t.Run("should return concrete values", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverCue,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		data := `
+#Port: int & >0 & <65536
+Server: {
+	host: string | *"localhost"
+	port: #Port & 8080
+	ratio: 0.5
+	tags: ["a", "b"]
+}
+`
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(strings.NewReader(data))
+			require.NoError(t, e)
+
+			assert.Equal(t, flam.Bag{
+				"server": flam.Bag{
+					"host":  "localhost",
+					"port":  8080,
+					"ratio": 0.5,
+					"tags":  []any{"a", "b"},
+				},
+			}, got)
+		}))
+	})
+
+	t.Run("should return schema disk not found error", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverCue,
+				"schema": "/schema.cue",
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(strings.NewReader("port: 80"))
+			assert.Nil(t, got)
+			assert.ErrorIs(t, e, config.ErrCueSchemaDiskNotFound)
+		}))
+	})
+
+	t.Run("should unify with the schema from the configured disk", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverCue,
+				"disk":   "my_disk",
+				"schema": "/schema.cue",
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		disk := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(disk, "/schema.cue", []byte(`
+port: int & >1024
+host: string | *"localhost"
+`), 0o644))
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, container.Invoke(func(facade filesystem.Facade) error {
+			return facade.AddDisk("my_disk", disk)
+		}))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(strings.NewReader("port: 8080"))
+			require.NoError(t, e)
+			assert.Equal(t, flam.Bag{"port": 8080, "host": "localhost"}, got)
+
+			got, e = parser.Parse(strings.NewReader("port: 80"))
+			assert.Nil(t, got)
+			assert.ErrorIs(t, e, config.ErrCueEvaluation)
+			assert.ErrorContains(t, e, "port: invalid value 80")
+
+			got, e = parser.Parse(strings.NewReader("host: \"remote\""))
+			assert.Nil(t, got)
+			assert.ErrorIs(t, e, config.ErrCueEvaluation)
+			assert.ErrorContains(t, e, "port: incomplete value")
+		}))
+	})
+
+	t.Run("should evaluate the source package with the source disk schema", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathBoot, true)
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverCue,
+				"schema": "/schema/config.cue",
+			}})
+		_ = config.Defaults.Set(config.PathSources, flam.Bag{
+			"my_source": flam.Bag{
+				"driver": config.SourceDriverFile,
+				"disk":   "my_disk",
+				"parser": "my_parser",
+				"path":   "/config/main.cue",
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		disk := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(disk, "/schema/config.cue", []byte(`
+db: {
+	host: string
+	port: int | *5432
+}
+`), 0o644))
+		require.NoError(t, afero.WriteFile(disk, "/config/main.cue", []byte(`
+package app
+
+db: host: "db.local"
+greeting: "hi " + name
+`), 0o644))
+		require.NoError(t, afero.WriteFile(disk, "/config/extra.cue", []byte(`
+package app
+
+name: "my_app"
+`), 0o644))
+		require.NoError(t, afero.WriteFile(disk, "/config/other.cue", []byte(`
+package other
+
+name: "other"
+`), 0o644))
+		require.NoError(t, afero.WriteFile(disk, "/config/notes.txt", []byte(`notes`), 0o644))
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, container.Invoke(func(facade filesystem.Facade) error {
+			return facade.AddDisk("my_disk", disk)
+		}))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			assert.Equal(t, "db.local", facade.Get("db.host"))
+			assert.Equal(t, 5432, facade.Get("db.port"))
+			assert.Equal(t, "my_app", facade.Get("name"))
+			assert.Equal(t, "hi my_app", facade.Get("greeting"))
+		}))
+	})
+}
@@ -0,0 +1,296 @@
+package tests
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/klauspost/compress/zstd"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/dig"
+
+	flam "github.com/happyhippyhippo/flam"
+	config "github.com/happyhippyhippo/flam-config"
+	mocks "github.com/happyhippyhippo/flam-config/tests/mocks"
+	filesystem "github.com/happyhippyhippo/flam-filesystem"
+	flamTime "github.com/happyhippyhippo/flam-time"
+)
+
+func gzipCompress(t *testing.T, data string) []byte {
+	buffer := &bytes.Buffer{}
+	writer := gzip.NewWriter(buffer)
+	_, e := writer.Write([]byte(data))
+	require.NoError(t, e)
+	require.NoError(t, writer.Close())
+
+	return buffer.Bytes()
+}
+
+func zstdCompress(t *testing.T, data string) []byte {
+	encoder, e := zstd.NewWriter(nil)
+	require.NoError(t, e)
+	defer func() { _ = encoder.Close() }()
+
+	return encoder.EncodeAll([]byte(data), nil)
+}
+
+func Test_decompress(t *testing.T) {
+	parsers := flam.Bag{
+		"yaml": flam.Bag{"driver": config.ParserDriverYaml},
+		"json": flam.Bag{"driver": config.ParserDriverJson},
+	}
+
+	t.Run("should decompress file sources", func(t *testing.T) {
+		scenarios := []struct {
+			test    string
+			path    string
+			parser  string
+			content []byte
+			maxSize int
+			err     error
+		}{
+			{
+				test:    "gzip by extension",
+				path:    "/config/file.json.gz",
+				content: gzipCompress(t, `{"field": "value"}`),
+			},
+			{
+				test:    "zstd by extension",
+				path:    "/config/file.yaml.zst",
+				content: zstdCompress(t, "field: value"),
+			},
+			{
+				test:    "gzip by magic bytes",
+				path:    "/config/file.yaml",
+				content: gzipCompress(t, "field: value"),
+			},
+			{
+				test:    "zstd by magic bytes",
+				path:    "/config/file.bundle",
+				parser:  "json",
+				content: zstdCompress(t, `{"field": "value"}`),
+			},
+			{
+				test:    "uncompressed content",
+				path:    "/config/file.json",
+				content: []byte(`{"field": "value"}`),
+				maxSize: 1,
+			},
+			{
+				test:    "invalid compressed content",
+				path:    "/config/file.json.gz",
+				content: []byte(`{"field": "value"}`),
+				err:     gzip.ErrHeader,
+			},
+			{
+				test:    "decompressed size over the limit",
+				path:    "/config/file.json.gz",
+				content: gzipCompress(t, `{"field": "value"}`),
+				maxSize: 10,
+				err:     config.ErrDecompressedSizeExceeded,
+			},
+		}
+
+		for _, scenario := range scenarios {
+			t.Run(scenario.test, func(t *testing.T) {
+				source := flam.Bag{
+					"driver": config.SourceDriverFile,
+					"disk":   "my_disk",
+					"path":   scenario.path,
+				}
+				if scenario.parser != "" {
+					source["parser"] = scenario.parser
+				}
+				if scenario.maxSize != 0 {
+					source["max_decompressed_size"] = scenario.maxSize
+				}
+
+				config.Defaults = flam.Bag{}
+				_ = config.Defaults.Set(config.PathBoot, true)
+				_ = config.Defaults.Set(config.PathParsers, parsers)
+				_ = config.Defaults.Set(config.PathSources, flam.Bag{"my_source": source})
+				defer func() { config.Defaults = flam.Bag{} }()
+
+				disk := afero.NewMemMapFs()
+				require.NoError(t, afero.WriteFile(disk, scenario.path, scenario.content, 0o644))
+
+				container := dig.New()
+				require.NoError(t, flamTime.NewProvider().Register(container))
+				require.NoError(t, filesystem.NewProvider().Register(container))
+				require.NoError(t, config.NewProvider().Register(container))
+				require.NoError(t, container.Invoke(func(facade filesystem.Facade) error {
+					return facade.AddDisk("my_disk", disk)
+				}))
+
+				e := config.NewProvider().(flam.BootableProvider).Boot(container)
+				if scenario.err != nil {
+					assert.ErrorIs(t, e, scenario.err)
+					return
+				}
+
+				require.NoError(t, e)
+				assert.NoError(t, container.Invoke(func(facade config.Facade) {
+					assert.Equal(t, "value", facade.Get("field"))
+				}))
+			})
+		}
+	})
+
+	t.Run("should decompress dir source files", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathBoot, true)
+		_ = config.Defaults.Set(config.PathParsers, parsers)
+		_ = config.Defaults.Set(config.PathSources, flam.Bag{
+			"my_source": flam.Bag{
+				"driver": config.SourceDriverDir,
+				"disk":   "my_disk",
+				"path":   "/config",
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		disk := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(disk, "/config/a.yaml.gz", gzipCompress(t, "yaml: value"), 0o644))
+		require.NoError(t, afero.WriteFile(disk, "/config/b.json.zstd", zstdCompress(t, `{"json": "value"}`), 0o644))
+		require.NoError(t, afero.WriteFile(disk, "/config/c.txt.gz", gzipCompress(t, "text"), 0o644))
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, container.Invoke(func(facade filesystem.Facade) error {
+			return facade.AddDisk("my_disk", disk)
+		}))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			assert.Equal(t, "value", facade.Get("yaml"))
+			assert.Equal(t, "value", facade.Get("json"))
+		}))
+	})
+
+	t.Run("should use the default decompressed size limit", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathBoot, true)
+		_ = config.Defaults.Set(config.PathDefaultMaxDecompressedSize, 10)
+		_ = config.Defaults.Set(config.PathParsers, parsers)
+		_ = config.Defaults.Set(config.PathSources, flam.Bag{
+			"my_source": flam.Bag{
+				"driver": config.SourceDriverFile,
+				"disk":   "my_disk",
+				"path":   "/config/file.json.gz",
+			}})
+		defaultMaxDecompressedSize := config.DefaultMaxDecompressedSize
+		defer func() {
+			config.Defaults = flam.Bag{}
+			config.DefaultMaxDecompressedSize = defaultMaxDecompressedSize
+		}()
+
+		disk := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(disk, "/config/file.json.gz", gzipCompress(t, `{"field": "value"}`), 0o644))
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, container.Invoke(func(facade filesystem.Facade) error {
+			return facade.AddDisk("my_disk", disk)
+		}))
+
+		assert.ErrorIs(
+			t,
+			config.NewProvider().(flam.BootableProvider).Boot(container),
+			config.ErrDecompressedSizeExceeded)
+	})
+
+	t.Run("should decompress rest responses", func(t *testing.T) {
+		scenarios := []struct {
+			test     string
+			encoding string
+			content  []byte
+			err      error
+		}{
+			{
+				test:     "gzip content encoding",
+				encoding: "gzip",
+				content:  gzipCompress(t, `{"config": {"field": "value"}}`),
+			},
+			{
+				test:     "zstd content encoding",
+				encoding: "zstd",
+				content:  zstdCompress(t, `{"config": {"field": "value"}}`),
+			},
+			{
+				test:    "gzip by magic bytes",
+				content: gzipCompress(t, `{"config": {"field": "value"}}`),
+			},
+			{
+				test:     "identity content encoding",
+				encoding: "identity",
+				content:  []byte(`{"config": {"field": "value"}}`),
+			},
+			{
+				test:     "unsupported content encoding",
+				encoding: "br",
+				content:  []byte(`{"config": {"field": "value"}}`),
+				err:      config.ErrUnsupportedContentEncoding,
+			},
+		}
+
+		for _, scenario := range scenarios {
+			t.Run(scenario.test, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				config.Defaults = flam.Bag{}
+				_ = config.Defaults.Set(config.PathBoot, true)
+				_ = config.Defaults.Set(config.PathParsers, parsers)
+				_ = config.Defaults.Set(config.PathSources, flam.Bag{
+					"my_source": flam.Bag{
+						"driver": config.SourceDriverRest,
+						"uri":    "http://uri",
+						"parser": "json",
+						"path":   flam.Bag{"config": "config"},
+					}})
+				defer func() { config.Defaults = flam.Bag{} }()
+
+				container := dig.New()
+				require.NoError(t, flamTime.NewProvider().Register(container))
+				require.NoError(t, filesystem.NewProvider().Register(container))
+				require.NoError(t, config.NewProvider().Register(container))
+
+				response := &http.Response{
+					Header: http.Header{},
+					Body:   io.NopCloser(bytes.NewReader(scenario.content)),
+				}
+				if scenario.encoding != "" {
+					response.Header.Set("Content-Encoding", scenario.encoding)
+				}
+
+				requester := mocks.NewRestRequester(ctrl)
+				requester.EXPECT().Do(gomock.Any()).Return(response, nil).Times(1)
+
+				requestGenerator := mocks.NewRestRequesterGenerator(ctrl)
+				requestGenerator.EXPECT().Create().Return(requester, nil).Times(1)
+				require.NoError(t, container.Decorate(func(generator config.RestRequesterGenerator) config.RestRequesterGenerator {
+					return requestGenerator
+				}))
+
+				e := config.NewProvider().(flam.BootableProvider).Boot(container)
+				if scenario.err != nil {
+					assert.ErrorIs(t, e, scenario.err)
+					return
+				}
+
+				require.NoError(t, e)
+				assert.NoError(t, container.Invoke(func(facade config.Facade) {
+					assert.Equal(t, "value", facade.Get("field"))
+				}))
+			})
+		}
+	})
+}
@@ -0,0 +1,183 @@
+package tests
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/dig"
+
+	flam "github.com/happyhippyhippo/flam"
+	config "github.com/happyhippyhippo/flam-config"
+	mocks "github.com/happyhippyhippo/flam-config/tests/mocks"
+	filesystem "github.com/happyhippyhippo/flam-filesystem"
+	flamTime "github.com/happyhippyhippo/flam-time"
+)
+
+func Test_dotenvParser(t *testing.T) {
+	t.Run("should return reader error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverDotenv,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		expectedErr := errors.New("reader error")
+		reader := mocks.NewReadCloser(ctrl)
+		reader.EXPECT().Read(gomock.Any()).Return(0, expectedErr).Times(1)
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(reader)
+			assert.Nil(t, got)
+			assert.ErrorIs(t, e, expectedErr)
+		}))
+	})
+
+	t.Run("should parse using the default separator", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverDotenv,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		data := `
+# comment
+APP_NAME=my app
+DB__HOST=localhost
+DB__PORT="5432"
+export DB__USER='admin'
+DB__POOL__SIZE=10 # inline comment
+`
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(strings.NewReader(data))
+			require.NoError(t, e)
+
+			assert.Equal(t, flam.Bag{
+				"app_name": "my app",
+				"db": flam.Bag{
+					"host": "localhost",
+					"port": "5432",
+					"user": "admin",
+					"pool": flam.Bag{"size": "10"},
+				},
+			}, got)
+
+			_, found := os.LookupEnv("DB__HOST")
+			assert.False(t, found)
+		}))
+	})
+
+	t.Run("should parse using a custom separator", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver":    config.ParserDriverDotenv,
+				"separator": "_",
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(strings.NewReader("DB_HOST=localhost\nDB_PORT=5432"))
+			require.NoError(t, e)
+
+			assert.Equal(t, flam.Bag{"db": flam.Bag{"host": "localhost", "port": "5432"}}, got)
+		}))
+	})
+
+	t.Run("should not nest keys with an empty separator", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver":    config.ParserDriverDotenv,
+				"separator": "",
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(strings.NewReader("DB__HOST=localhost"))
+			require.NoError(t, e)
+
+			assert.Equal(t, flam.Bag{"db__host": "localhost"}, got)
+		}))
+	})
+
+	t.Run("should be usable by a file source", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathBoot, true)
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverDotenv,
+			}})
+		_ = config.Defaults.Set(config.PathSources, flam.Bag{
+			"my_source": flam.Bag{
+				"driver": config.SourceDriverFile,
+				"disk":   "my_disk",
+				"parser": "my_parser",
+				"path":   "/config/.env",
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		disk := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(disk, "/config/.env", []byte("DB__HOST=localhost"), 0o644))
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, container.Invoke(func(facade filesystem.Facade) error {
+			return facade.AddDisk("my_disk", disk)
+		}))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			assert.Equal(t, "localhost", facade.Get("db.host"))
+		}))
+	})
+}
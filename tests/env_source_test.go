@@ -2,9 +2,11 @@ package tests
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/dig"
@@ -81,4 +83,518 @@ func Test_envSource(t *testing.T) {
 			assert.Equal(t, nil, got.Get("env.invalid"))
 		}))
 	})
+
+	t.Run("should map prefixed variables", func(t *testing.T) {
+		scenarios := []struct {
+			test     string
+			source   flam.Bag
+			expected any
+			err      error
+		}{
+			{
+				test:   "default separator and case",
+				source: flam.Bag{"prefix": "MYAPP_"},
+				expected: flam.Bag{
+					"db":   flam.Bag{"host": "localhost", "max_conns": "10"},
+					"name": "my app",
+					"url":  "mapped",
+				},
+			},
+			{
+				test:   "custom separator",
+				source: flam.Bag{"prefix": "MYAPP_", "separator": "_"},
+				expected: flam.Bag{
+					"name": "my app",
+					"url":  "mapped",
+				},
+			},
+			{
+				test:   "preserved case",
+				source: flam.Bag{"prefix": "MYAPP_", "case": config.EnvCasePreserve},
+				expected: flam.Bag{
+					"DB":   flam.Bag{"HOST": "localhost", "MAX_CONNS": "10"},
+					"NAME": "my app",
+					"url":  "mapped",
+				},
+			},
+			{
+				test:   "explicit mappings take precedence",
+				source: flam.Bag{"prefix": "MYAPP_", "mappings": flam.Bag{"MYAPP_DB__HOST": "database.host", "MYAPP_NAME": "name"}},
+				expected: flam.Bag{
+					"database": flam.Bag{"host": "localhost"},
+					"db":       flam.Bag{"max_conns": "10"},
+					"name":     "my app",
+					"url":      "mapped",
+				},
+			},
+			{
+				test:   "invalid case rule",
+				source: flam.Bag{"prefix": "MYAPP_", "case": "title"},
+				err:    config.ErrEnvInvalidCase,
+			},
+		}
+
+		for _, scenario := range scenarios {
+			t.Run(scenario.test, func(t *testing.T) {
+				t.Setenv("MYAPP_DB__HOST", "localhost")
+				t.Setenv("MYAPP_DB__MAX_CONNS", "10")
+				t.Setenv("MYAPP_NAME", "my app")
+				t.Setenv("MYAPP_", "ignored")
+				t.Setenv("MYAPP_DB____EMPTY", "ignored")
+				t.Setenv("OTHER_NAME", "ignored")
+				t.Setenv("MYAPP_URL_VALUE", "mapped")
+
+				source := flam.Bag{"driver": config.SourceDriverEnv, "mappings": flam.Bag{"MYAPP_URL_VALUE": "url"}}
+				source.Merge(scenario.source)
+
+				config.Defaults = flam.Bag{}
+				_ = config.Defaults.Set(config.PathBoot, true)
+				_ = config.Defaults.Set(config.PathSources, flam.Bag{"my_source": source})
+				defer func() { config.Defaults = flam.Bag{} }()
+
+				container := dig.New()
+				require.NoError(t, time.NewProvider().Register(container))
+				require.NoError(t, filesystem.NewProvider().Register(container))
+				require.NoError(t, config.NewProvider().Register(container))
+
+				e := config.NewProvider().(flam.BootableProvider).Boot(container)
+				if scenario.err != nil {
+					assert.ErrorIs(t, e, scenario.err)
+					return
+				}
+
+				require.NoError(t, e)
+				assert.NoError(t, container.Invoke(func(facade config.Facade) {
+					got, e := facade.GetSource("my_source")
+					require.NoError(t, e)
+
+					assert.Equal(t, scenario.expected, got.Get(""))
+				}))
+			})
+		}
+	})
+
+	t.Run("should coerce env values", func(t *testing.T) {
+		scenarios := []struct {
+			test     string
+			source   flam.Bag
+			env      map[string]string
+			expected any
+			err      error
+		}{
+			{
+				test:     "values are strings by default",
+				source:   flam.Bag{"prefix": "MYAPP_"},
+				env:      map[string]string{"MYAPP_PORT": "8080", "MYAPP_DEBUG": "true"},
+				expected: flam.Bag{"port": "8080", "debug": "true"},
+			},
+			{
+				test:   "inferred prefixed values",
+				source: flam.Bag{"prefix": "MYAPP_", "infer": true},
+				env: map[string]string{
+					"MYAPP_PORT":    "8080",
+					"MYAPP_RATIO":   "0.5",
+					"MYAPP_DEBUG":   "TRUE",
+					"MYAPP_OFF":     "false",
+					"MYAPP_HOSTS":   `["a", "b"]`,
+					"MYAPP_DB":      `{"Host": "localhost"}`,
+					"MYAPP_NAME":    "my app",
+					"MYAPP_INF":     "inf",
+					"MYAPP_INVALID": "{invalid",
+				},
+				expected: flam.Bag{
+					"port":    8080,
+					"ratio":   0.5,
+					"debug":   true,
+					"off":     false,
+					"hosts":   []any{"a", "b"},
+					"db":      flam.Bag{"host": "localhost"},
+					"name":    "my app",
+					"inf":     "inf",
+					"invalid": "{invalid",
+				},
+			},
+			{
+				test:     "inferred mapped values",
+				source:   flam.Bag{"infer": true, "mappings": flam.Bag{"MYAPP_PORT": "db.port"}},
+				env:      map[string]string{"MYAPP_PORT": "5432"},
+				expected: flam.Bag{"db": flam.Bag{"port": 5432}},
+			},
+			{
+				test: "declared types",
+				source: flam.Bag{
+					"infer": true,
+					"mappings": flam.Bag{
+						"MYAPP_PORT":  flam.Bag{"path": "db.port", "type": "int"},
+						"MYAPP_RATIO": flam.Bag{"path": "db.ratio", "type": "float"},
+						"MYAPP_DEBUG": flam.Bag{"path": "db.debug", "type": "bool"},
+						"MYAPP_HOSTS": flam.Bag{"path": "db.hosts", "type": "list"},
+						"MYAPP_PATHS": flam.Bag{"path": "db.paths", "type": "list", "sep": ":"},
+						"MYAPP_OPTS":  flam.Bag{"path": "db.opts", "type": "json"},
+						"MYAPP_CODE":  flam.Bag{"path": "db.code", "type": "string"},
+						"MYAPP_NAME":  flam.Bag{"path": "db.name"},
+					},
+				},
+				env: map[string]string{
+					"MYAPP_PORT":  " 5432 ",
+					"MYAPP_RATIO": "1.5",
+					"MYAPP_DEBUG": "1",
+					"MYAPP_HOSTS": "a, b,,c",
+					"MYAPP_PATHS": "/bin:/usr/bin",
+					"MYAPP_OPTS":  `{"Timeout": 5}`,
+					"MYAPP_CODE":  "007",
+					"MYAPP_NAME":  "10",
+				},
+				expected: flam.Bag{"db": flam.Bag{
+					"port":  5432,
+					"ratio": 1.5,
+					"debug": true,
+					"hosts": []any{"a", "b", "c"},
+					"paths": []any{"/bin", "/usr/bin"},
+					"opts":  flam.Bag{"timeout": 5},
+					"code":  "007",
+					"name":  10,
+				}},
+			},
+			{
+				test:   "invalid declared type",
+				source: flam.Bag{"mappings": flam.Bag{"MYAPP_PORT": flam.Bag{"path": "db.port", "type": "complex"}}},
+				env:    map[string]string{"MYAPP_PORT": "5432"},
+				err:    config.ErrEnvInvalidType,
+			},
+			{
+				test:   "invalid int value",
+				source: flam.Bag{"mappings": flam.Bag{"MYAPP_PORT": flam.Bag{"path": "db.port", "type": "int"}}},
+				env:    map[string]string{"MYAPP_PORT": "abc"},
+				err:    config.ErrEnvInvalidValue,
+			},
+			{
+				test:   "invalid float value",
+				source: flam.Bag{"mappings": flam.Bag{"MYAPP_RATIO": flam.Bag{"path": "db.ratio", "type": "float"}}},
+				env:    map[string]string{"MYAPP_RATIO": "abc"},
+				err:    config.ErrEnvInvalidValue,
+			},
+			{
+				test:   "invalid bool value",
+				source: flam.Bag{"mappings": flam.Bag{"MYAPP_DEBUG": flam.Bag{"path": "db.debug", "type": "bool"}}},
+				env:    map[string]string{"MYAPP_DEBUG": "abc"},
+				err:    config.ErrEnvInvalidValue,
+			},
+			{
+				test:   "invalid json value",
+				source: flam.Bag{"mappings": flam.Bag{"MYAPP_OPTS": flam.Bag{"path": "db.opts", "type": "json"}}},
+				env:    map[string]string{"MYAPP_OPTS": "{"},
+				err:    config.ErrEnvInvalidValue,
+			},
+		}
+
+		for _, scenario := range scenarios {
+			t.Run(scenario.test, func(t *testing.T) {
+				for key, value := range scenario.env {
+					t.Setenv(key, value)
+				}
+
+				source := flam.Bag{"driver": config.SourceDriverEnv}
+				source.Merge(scenario.source)
+
+				config.Defaults = flam.Bag{}
+				_ = config.Defaults.Set(config.PathBoot, true)
+				_ = config.Defaults.Set(config.PathSources, flam.Bag{"my_source": source})
+				defer func() { config.Defaults = flam.Bag{} }()
+
+				container := dig.New()
+				require.NoError(t, time.NewProvider().Register(container))
+				require.NoError(t, filesystem.NewProvider().Register(container))
+				require.NoError(t, config.NewProvider().Register(container))
+
+				e := config.NewProvider().(flam.BootableProvider).Boot(container)
+				if scenario.err != nil {
+					assert.ErrorIs(t, e, scenario.err)
+					return
+				}
+
+				require.NoError(t, e)
+				assert.NoError(t, container.Invoke(func(facade config.Facade) {
+					got, e := facade.GetSource("my_source")
+					require.NoError(t, e)
+
+					assert.Equal(t, scenario.expected, got.Get(""))
+				}))
+			})
+		}
+	})
+
+	t.Run("should read env files without mutating the process environment", func(t *testing.T) {
+		t.Setenv("ISOLATED_SYSTEM_FIELD", "system_value")
+
+		file := filepath.Join(t.TempDir(), ".env")
+		require.NoError(t, os.WriteFile(file, []byte("ISOLATED_FILE_FIELD=file_value\nISOLATED_SYSTEM_FIELD=file_value\nISOLATED_DB__HOST=localhost\n"), 0o644))
+
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathBoot, true)
+		_ = config.Defaults.Set(config.PathSources, flam.Bag{
+			"my_source": flam.Bag{
+				"driver":   config.SourceDriverEnv,
+				"isolated": true,
+				"files":    []string{file},
+				"prefix":   "ISOLATED_DB__",
+				"mappings": flam.Bag{
+					"ISOLATED_FILE_FIELD":   "env.file_field",
+					"ISOLATED_SYSTEM_FIELD": "env.system_field",
+				},
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, time.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			assert.Equal(t, "file_value", facade.Get("env.file_field"))
+			assert.Equal(t, "system_value", facade.Get("env.system_field"))
+			assert.Equal(t, "localhost", facade.Get("host"))
+		}))
+
+		_, ok := os.LookupEnv("ISOLATED_FILE_FIELD")
+		assert.False(t, ok)
+	})
+
+	t.Run("should return isolated env file reading error", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathBoot, true)
+		_ = config.Defaults.Set(config.PathSources, flam.Bag{
+			"my_source": flam.Bag{
+				"driver":   config.SourceDriverEnv,
+				"isolated": true,
+				"files":    []string{"./testdata/invalid"},
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, time.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+
+		assert.ErrorContains(
+			t,
+			config.NewProvider().(flam.BootableProvider).Boot(container),
+			"no such file or directory")
+	})
+
+	t.Run("should read mapped values from _FILE variables", func(t *testing.T) {
+		scenarios := []struct {
+			test     string
+			env      map[string]string
+			mapping  any
+			disk     string
+			expected any
+			msg      string
+		}{
+			{
+				test:     "trailing newline",
+				env:      map[string]string{"SECRET_FILE": "/run/secrets/newline"},
+				mapping:  "db.password",
+				expected: "s3cret",
+			},
+			{
+				test:     "trailing carriage return and newline",
+				env:      map[string]string{"SECRET_FILE": "/run/secrets/crlf"},
+				mapping:  "db.password",
+				expected: "s3cret",
+			},
+			{
+				test:     "only the last newline is trimmed",
+				env:      map[string]string{"SECRET_FILE": "/run/secrets/multiline"},
+				mapping:  "db.password",
+				expected: "line 1\nline 2\n",
+			},
+			{
+				test:     "direct value takes precedence",
+				env:      map[string]string{"SECRET": "direct", "SECRET_FILE": "/run/secrets/newline"},
+				mapping:  "db.password",
+				expected: "direct",
+			},
+			{
+				test:     "declared type",
+				env:      map[string]string{"SECRET_FILE": "/run/secrets/port"},
+				mapping:  flam.Bag{"path": "db.password", "type": "int"},
+				expected: 5432,
+			},
+			{
+				test:     "empty file",
+				env:      map[string]string{"SECRET_FILE": "/run/secrets/empty"},
+				mapping:  "db.password",
+				expected: nil,
+			},
+			{
+				test:    "missing file",
+				env:     map[string]string{"SECRET_FILE": "/run/secrets/missing"},
+				mapping: "db.password",
+				msg:     "file does not exist",
+			},
+			{
+				test:    "unknown disk",
+				env:     map[string]string{"SECRET_FILE": "/run/secrets/newline"},
+				mapping: "db.password",
+				disk:    "unknown_disk",
+				msg:     "unknown_disk",
+			},
+		}
+
+		for _, scenario := range scenarios {
+			t.Run(scenario.test, func(t *testing.T) {
+				t.Setenv("SECRET", "")
+				for key, value := range scenario.env {
+					t.Setenv(key, value)
+				}
+
+				disk := scenario.disk
+				if disk == "" {
+					disk = "my_disk"
+				}
+
+				config.Defaults = flam.Bag{}
+				_ = config.Defaults.Set(config.PathBoot, true)
+				_ = config.Defaults.Set(config.PathSources, flam.Bag{
+					"my_source": flam.Bag{
+						"driver":   config.SourceDriverEnv,
+						"disk":     disk,
+						"mappings": flam.Bag{"SECRET": scenario.mapping},
+					}})
+				defer func() { config.Defaults = flam.Bag{} }()
+
+				secrets := afero.NewMemMapFs()
+				require.NoError(t, afero.WriteFile(secrets, "/run/secrets/newline", []byte("s3cret\n"), 0o600))
+				require.NoError(t, afero.WriteFile(secrets, "/run/secrets/crlf", []byte("s3cret\r\n"), 0o600))
+				require.NoError(t, afero.WriteFile(secrets, "/run/secrets/multiline", []byte("line 1\nline 2\n\n"), 0o600))
+				require.NoError(t, afero.WriteFile(secrets, "/run/secrets/port", []byte("5432\n"), 0o600))
+				require.NoError(t, afero.WriteFile(secrets, "/run/secrets/empty", []byte(""), 0o600))
+
+				container := dig.New()
+				require.NoError(t, time.NewProvider().Register(container))
+				require.NoError(t, filesystem.NewProvider().Register(container))
+				require.NoError(t, config.NewProvider().Register(container))
+				require.NoError(t, container.Invoke(func(facade filesystem.Facade) error {
+					return facade.AddDisk("my_disk", secrets)
+				}))
+
+				e := config.NewProvider().(flam.BootableProvider).Boot(container)
+				if scenario.msg != "" {
+					assert.ErrorContains(t, e, scenario.msg)
+					return
+				}
+
+				require.NoError(t, e)
+				assert.NoError(t, container.Invoke(func(facade config.Facade) {
+					assert.Equal(t, scenario.expected, facade.Get("db.password"))
+				}))
+			})
+		}
+	})
+
+	t.Run("should read prefixed values from _FILE variables", func(t *testing.T) {
+		scenarios := []struct {
+			test        string
+			env         map[string]string
+			prefixFiles bool
+			disk        string
+			expected    any
+			msg         string
+		}{
+			{
+				test:     "plain value without opt-in",
+				env:      map[string]string{"MYAPP_LOG_FILE": "/var/log/app.log"},
+				disk:     "my_disk",
+				expected: flam.Bag{"log_file": "/var/log/app.log"},
+			},
+			{
+				test:     "plain value without opt-in nor disk",
+				env:      map[string]string{"MYAPP_LOG_FILE": "/var/log/app.log"},
+				expected: flam.Bag{"log_file": "/var/log/app.log"},
+			},
+			{
+				test:        "file value stored under the base path",
+				env:         map[string]string{"MYAPP_DB__PASSWORD_FILE": "/run/secrets/password"},
+				prefixFiles: true,
+				disk:        "my_disk",
+				expected:    flam.Bag{"db": flam.Bag{"password": "s3cret"}},
+			},
+			{
+				test:        "file value is inferred",
+				env:         map[string]string{"MYAPP_DB__PORT_FILE": "/run/secrets/port"},
+				prefixFiles: true,
+				disk:        "my_disk",
+				expected:    flam.Bag{"db": flam.Bag{"port": 5432}},
+			},
+			{
+				test:        "direct value takes precedence",
+				env:         map[string]string{"MYAPP_DB__PASSWORD": "direct", "MYAPP_DB__PASSWORD_FILE": "/run/secrets/password"},
+				prefixFiles: true,
+				disk:        "my_disk",
+				expected:    flam.Bag{"db": flam.Bag{"password": "direct"}},
+			},
+			{
+				test:        "empty file",
+				env:         map[string]string{"MYAPP_DB__PASSWORD_FILE": "/run/secrets/empty"},
+				prefixFiles: true,
+				disk:        "my_disk",
+				expected:    flam.Bag{},
+			},
+			{
+				test:        "missing file",
+				env:         map[string]string{"MYAPP_DB__PASSWORD_FILE": "/run/secrets/missing"},
+				prefixFiles: true,
+				disk:        "my_disk",
+				msg:         "file does not exist",
+			},
+		}
+
+		for _, scenario := range scenarios {
+			t.Run(scenario.test, func(t *testing.T) {
+				for key, value := range scenario.env {
+					t.Setenv(key, value)
+				}
+
+				config.Defaults = flam.Bag{}
+				_ = config.Defaults.Set(config.PathBoot, true)
+				_ = config.Defaults.Set(config.PathSources, flam.Bag{
+					"my_source": flam.Bag{
+						"driver":       config.SourceDriverEnv,
+						"disk":         scenario.disk,
+						"prefix":       "MYAPP_",
+						"infer":        true,
+						"prefix_files": scenario.prefixFiles,
+					}})
+				defer func() { config.Defaults = flam.Bag{} }()
+
+				secrets := afero.NewMemMapFs()
+				require.NoError(t, afero.WriteFile(secrets, "/run/secrets/password", []byte("s3cret\n"), 0o600))
+				require.NoError(t, afero.WriteFile(secrets, "/run/secrets/port", []byte("5432\n"), 0o600))
+				require.NoError(t, afero.WriteFile(secrets, "/run/secrets/empty", []byte(""), 0o600))
+
+				container := dig.New()
+				require.NoError(t, time.NewProvider().Register(container))
+				require.NoError(t, filesystem.NewProvider().Register(container))
+				require.NoError(t, config.NewProvider().Register(container))
+				require.NoError(t, container.Invoke(func(facade filesystem.Facade) error {
+					return facade.AddDisk("my_disk", secrets)
+				}))
+
+				e := config.NewProvider().(flam.BootableProvider).Boot(container)
+				if scenario.msg != "" {
+					assert.ErrorContains(t, e, scenario.msg)
+					return
+				}
+
+				require.NoError(t, e)
+				assert.NoError(t, container.Invoke(func(facade config.Facade) {
+					got, e := facade.GetSource("my_source")
+					require.NoError(t, e)
+					assert.Equal(t, scenario.expected, got.Get(""))
+				}))
+			})
+		}
+	})
 }
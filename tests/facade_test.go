@@ -1902,6 +1902,300 @@ func Test_Facade_GetParser(t *testing.T) {
 			assert.NoError(t, e)
 		}))
 	})
+
+	t.Run("should return 'toml' parser", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		container := dig.New()
+		require.NoError(t, config.NewProvider().Register(container))
+
+		cfg := flam.Bag{"parser": flam.Bag{"driver": config.ParserDriverToml}}
+		factoryConfig := mocks.NewFactoryConfig(ctrl)
+		factoryConfig.EXPECT().Get(config.PathParsers).Return(cfg).Times(1)
+		require.NoError(t, container.Decorate(func(flam.FactoryConfig) flam.FactoryConfig {
+			return factoryConfig
+		}))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			got, e := facade.GetParser("parser")
+			assert.NotNil(t, got)
+			assert.NoError(t, e)
+		}))
+	})
+
+	t.Run("should return 'ini' parser", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		container := dig.New()
+		require.NoError(t, config.NewProvider().Register(container))
+
+		cfg := flam.Bag{"parser": flam.Bag{"driver": config.ParserDriverIni}}
+		factoryConfig := mocks.NewFactoryConfig(ctrl)
+		factoryConfig.EXPECT().Get(config.PathParsers).Return(cfg).Times(1)
+		require.NoError(t, container.Decorate(func(flam.FactoryConfig) flam.FactoryConfig {
+			return factoryConfig
+		}))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			got, e := facade.GetParser("parser")
+			assert.NotNil(t, got)
+			assert.NoError(t, e)
+		}))
+	})
+
+	t.Run("should return 'properties' parser", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		container := dig.New()
+		require.NoError(t, config.NewProvider().Register(container))
+
+		cfg := flam.Bag{"parser": flam.Bag{"driver": config.ParserDriverProperties}}
+		factoryConfig := mocks.NewFactoryConfig(ctrl)
+		factoryConfig.EXPECT().Get(config.PathParsers).Return(cfg).Times(1)
+		require.NoError(t, container.Decorate(func(flam.FactoryConfig) flam.FactoryConfig {
+			return factoryConfig
+		}))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			got, e := facade.GetParser("parser")
+			assert.NotNil(t, got)
+			assert.NoError(t, e)
+		}))
+	})
+
+	t.Run("should return 'hcl' parser", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		container := dig.New()
+		require.NoError(t, config.NewProvider().Register(container))
+
+		cfg := flam.Bag{"parser": flam.Bag{"driver": config.ParserDriverHcl}}
+		factoryConfig := mocks.NewFactoryConfig(ctrl)
+		factoryConfig.EXPECT().Get(config.PathParsers).Return(cfg).Times(1)
+		require.NoError(t, container.Decorate(func(flam.FactoryConfig) flam.FactoryConfig {
+			return factoryConfig
+		}))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			got, e := facade.GetParser("parser")
+			assert.NotNil(t, got)
+			assert.NoError(t, e)
+		}))
+	})
+
+	t.Run("should return 'xml' parser", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		container := dig.New()
+		require.NoError(t, config.NewProvider().Register(container))
+
+		cfg := flam.Bag{"parser": flam.Bag{"driver": config.ParserDriverXml}}
+		factoryConfig := mocks.NewFactoryConfig(ctrl)
+		factoryConfig.EXPECT().Get(config.PathParsers).Return(cfg).Times(1)
+		require.NoError(t, container.Decorate(func(flam.FactoryConfig) flam.FactoryConfig {
+			return factoryConfig
+		}))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			got, e := facade.GetParser("parser")
+			assert.NotNil(t, got)
+			assert.NoError(t, e)
+		}))
+	})
+
+	t.Run("should return 'json5' parser", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		container := dig.New()
+		require.NoError(t, config.NewProvider().Register(container))
+
+		cfg := flam.Bag{"parser": flam.Bag{"driver": config.ParserDriverJson5}}
+		factoryConfig := mocks.NewFactoryConfig(ctrl)
+		factoryConfig.EXPECT().Get(config.PathParsers).Return(cfg).Times(1)
+		require.NoError(t, container.Decorate(func(flam.FactoryConfig) flam.FactoryConfig {
+			return factoryConfig
+		}))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			got, e := facade.GetParser("parser")
+			assert.NotNil(t, got)
+			assert.NoError(t, e)
+		}))
+	})
+
+	t.Run("should return 'hocon' parser", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		container := dig.New()
+		require.NoError(t, config.NewProvider().Register(container))
+
+		cfg := flam.Bag{"parser": flam.Bag{"driver": config.ParserDriverHocon}}
+		factoryConfig := mocks.NewFactoryConfig(ctrl)
+		factoryConfig.EXPECT().Get(config.PathParsers).Return(cfg).Times(1)
+		require.NoError(t, container.Decorate(func(flam.FactoryConfig) flam.FactoryConfig {
+			return factoryConfig
+		}))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			got, e := facade.GetParser("parser")
+			assert.NotNil(t, got)
+			assert.NoError(t, e)
+		}))
+	})
+
+	t.Run("should return 'jsonnet' parser", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		container := dig.New()
+		require.NoError(t, config.NewProvider().Register(container))
+
+		cfg := flam.Bag{"parser": flam.Bag{"driver": config.ParserDriverJsonnet}}
+		factoryConfig := mocks.NewFactoryConfig(ctrl)
+		factoryConfig.EXPECT().Get(config.PathParsers).Return(cfg).Times(1)
+		require.NoError(t, container.Decorate(func(flam.FactoryConfig) flam.FactoryConfig {
+			return factoryConfig
+		}))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			got, e := facade.GetParser("parser")
+			assert.NotNil(t, got)
+			assert.NoError(t, e)
+		}))
+	})
+
+	t.Run("should return 'cue' parser", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		container := dig.New()
+		require.NoError(t, config.NewProvider().Register(container))
+
+		cfg := flam.Bag{"parser": flam.Bag{"driver": config.ParserDriverCue}}
+		factoryConfig := mocks.NewFactoryConfig(ctrl)
+		factoryConfig.EXPECT().Get(config.PathParsers).Return(cfg).Times(1)
+		require.NoError(t, container.Decorate(func(flam.FactoryConfig) flam.FactoryConfig {
+			return factoryConfig
+		}))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			got, e := facade.GetParser("parser")
+			assert.NotNil(t, got)
+			assert.NoError(t, e)
+		}))
+	})
+
+	t.Run("should return 'starlark' parser", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		container := dig.New()
+		require.NoError(t, config.NewProvider().Register(container))
+
+		cfg := flam.Bag{"parser": flam.Bag{"driver": config.ParserDriverStarlark}}
+		factoryConfig := mocks.NewFactoryConfig(ctrl)
+		factoryConfig.EXPECT().Get(config.PathParsers).Return(cfg).Times(1)
+		require.NoError(t, container.Decorate(func(flam.FactoryConfig) flam.FactoryConfig {
+			return factoryConfig
+		}))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			got, e := facade.GetParser("parser")
+			assert.NotNil(t, got)
+			assert.NoError(t, e)
+		}))
+	})
+
+	t.Run("should return 'msgpack' parser", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		container := dig.New()
+		require.NoError(t, config.NewProvider().Register(container))
+
+		cfg := flam.Bag{"parser": flam.Bag{"driver": config.ParserDriverMessagePack}}
+		factoryConfig := mocks.NewFactoryConfig(ctrl)
+		factoryConfig.EXPECT().Get(config.PathParsers).Return(cfg).Times(1)
+		require.NoError(t, container.Decorate(func(flam.FactoryConfig) flam.FactoryConfig {
+			return factoryConfig
+		}))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			got, e := facade.GetParser("parser")
+			assert.NotNil(t, got)
+			assert.NoError(t, e)
+		}))
+	})
+
+	t.Run("should return 'cbor' parser", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		container := dig.New()
+		require.NoError(t, config.NewProvider().Register(container))
+
+		cfg := flam.Bag{"parser": flam.Bag{"driver": config.ParserDriverCbor}}
+		factoryConfig := mocks.NewFactoryConfig(ctrl)
+		factoryConfig.EXPECT().Get(config.PathParsers).Return(cfg).Times(1)
+		require.NoError(t, container.Decorate(func(flam.FactoryConfig) flam.FactoryConfig {
+			return factoryConfig
+		}))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			got, e := facade.GetParser("parser")
+			assert.NotNil(t, got)
+			assert.NoError(t, e)
+		}))
+	})
+
+	t.Run("should return 'dotenv' parser", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		container := dig.New()
+		require.NoError(t, config.NewProvider().Register(container))
+
+		cfg := flam.Bag{"parser": flam.Bag{"driver": config.ParserDriverDotenv}}
+		factoryConfig := mocks.NewFactoryConfig(ctrl)
+		factoryConfig.EXPECT().Get(config.PathParsers).Return(cfg).Times(1)
+		require.NoError(t, container.Decorate(func(flam.FactoryConfig) flam.FactoryConfig {
+			return factoryConfig
+		}))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			got, e := facade.GetParser("parser")
+			assert.NotNil(t, got)
+			assert.NoError(t, e)
+		}))
+	})
+
+	t.Run("should return 'sops' parser", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		container := dig.New()
+		require.NoError(t, config.NewProvider().Register(container))
+
+		cfg := flam.Bag{"parser": flam.Bag{"driver": config.ParserDriverSops}}
+		factoryConfig := mocks.NewFactoryConfig(ctrl)
+		factoryConfig.EXPECT().Get(config.PathParsers).Return(cfg).Times(1)
+		require.NoError(t, container.Decorate(func(flam.FactoryConfig) flam.FactoryConfig {
+			return factoryConfig
+		}))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			got, e := facade.GetParser("parser")
+			assert.NotNil(t, got)
+			assert.NoError(t, e)
+		}))
+	})
 }
 
 func Test_Facade_AddParser(t *testing.T) {
@@ -0,0 +1,217 @@
+package tests
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/dig"
+
+	flam "github.com/happyhippyhippo/flam"
+	config "github.com/happyhippyhippo/flam-config"
+	filesystem "github.com/happyhippyhippo/flam-filesystem"
+	flamTime "github.com/happyhippyhippo/flam-time"
+)
+
+func Test_flagsSource(t *testing.T) {
+	mappings := flam.Bag{
+		"name":    "app.name",
+		"port":    flam.Bag{"path": "server.port", "type": "int", "usage": "server port"},
+		"debug":   flam.Bag{"path": "app.debug", "type": "bool"},
+		"ratio":   flam.Bag{"path": "app.ratio", "type": "float"},
+		"timeout": flam.Bag{"path": "server.timeout", "type": "duration"},
+		"hosts":   flam.Bag{"path": "server.hosts", "type": "list"},
+		"region":  flam.Bag{"type": "string"},
+	}
+
+	t.Run("should populate explicitly set flags", func(t *testing.T) {
+		scenarios := []struct {
+			test     string
+			args     any
+			expected flam.Bag
+			err      error
+			msg      string
+		}{
+			{
+				test:     "no flags",
+				args:     []string{},
+				expected: flam.Bag{},
+			},
+			{
+				test: "every flag type",
+				args: []any{
+					"-name", "my app",
+					"--port=8080",
+					"-debug",
+					"-ratio", "0.5",
+					"-timeout", "5s",
+					"-hosts", "a,b",
+					"-hosts", "c",
+					"-region", "eu",
+					"positional",
+				},
+				expected: flam.Bag{
+					"app": flam.Bag{
+						"name":  "my app",
+						"debug": true,
+						"ratio": 0.5,
+					},
+					"server": flam.Bag{
+						"port":    8080,
+						"timeout": 5 * time.Second,
+						"hosts":   []any{"a", "b", "c"},
+					},
+					"region": "eu",
+				},
+			},
+			{
+				test:     "explicit zero values",
+				args:     []string{"-debug=false", "-port", "0", "-name", ""},
+				expected: flam.Bag{"app": flam.Bag{"debug": false, "name": ""}, "server": flam.Bag{"port": 0}},
+			},
+			{
+				test: "foreign flags",
+				args: []string{
+					"-unknown",
+					"-test.v=true",
+					"--other", "value",
+					"-port", "8080",
+					"--debug",
+					"-test.run", "Test_flagsSource",
+				},
+				expected: flam.Bag{"app": flam.Bag{"debug": true}, "server": flam.Bag{"port": 8080}},
+			},
+			{
+				test:     "arguments after terminator",
+				args:     []string{"-port", "8080", "--", "-name", "ignored"},
+				expected: flam.Bag{"server": flam.Bag{"port": 8080}},
+			},
+			{
+				test: "invalid value",
+				args: []string{"-port", "abc"},
+				msg:  "invalid value \"abc\" for flag -port",
+			},
+			{
+				test: "help request",
+				args: []string{"-unknown", "-h"},
+				err:  config.ErrFlagsHelp,
+				msg:  "server port",
+			},
+		}
+
+		for _, scenario := range scenarios {
+			t.Run(scenario.test, func(t *testing.T) {
+				config.Defaults = flam.Bag{}
+				_ = config.Defaults.Set(config.PathBoot, true)
+				_ = config.Defaults.Set(config.PathSources, flam.Bag{
+					"my_source": flam.Bag{
+						"driver":   config.SourceDriverFlags,
+						"priority": 123,
+						"args":     scenario.args,
+						"mappings": mappings,
+					}})
+				defer func() { config.Defaults = flam.Bag{} }()
+
+				container := dig.New()
+				require.NoError(t, flamTime.NewProvider().Register(container))
+				require.NoError(t, filesystem.NewProvider().Register(container))
+				require.NoError(t, config.NewProvider().Register(container))
+
+				e := config.NewProvider().(flam.BootableProvider).Boot(container)
+				switch {
+				case scenario.err != nil:
+					assert.ErrorIs(t, e, scenario.err)
+					assert.ErrorContains(t, e, scenario.msg)
+					return
+				case scenario.msg != "":
+					assert.ErrorContains(t, e, scenario.msg)
+					return
+				}
+
+				require.NoError(t, e)
+				assert.NoError(t, container.Invoke(func(facade config.Facade) {
+					got, e := facade.GetSource("my_source")
+					require.NoError(t, e)
+
+					assert.Equal(t, 123, got.GetPriority())
+					assert.Equal(t, scenario.expected, got.Get(""))
+				}))
+			})
+		}
+	})
+
+	t.Run("should return invalid flag type error", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathBoot, true)
+		_ = config.Defaults.Set(config.PathSources, flam.Bag{
+			"my_source": flam.Bag{
+				"driver":   config.SourceDriverFlags,
+				"args":     []string{},
+				"mappings": flam.Bag{"port": flam.Bag{"path": "server.port", "type": "complex"}},
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+
+		assert.ErrorIs(
+			t,
+			config.NewProvider().(flam.BootableProvider).Boot(container),
+			config.ErrFlagsInvalidType)
+	})
+
+	t.Run("should parse the process arguments by default", func(t *testing.T) {
+		args := os.Args
+		os.Args = []string{"app", "-test.v", "-config", "app.yaml", "-port", "9090"}
+		defer func() { os.Args = args }()
+
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathBoot, true)
+		_ = config.Defaults.Set(config.PathSources, flam.Bag{
+			"my_source": flam.Bag{
+				"driver":   config.SourceDriverFlags,
+				"mappings": flam.Bag{"port": flam.Bag{"path": "server.port", "type": "int"}},
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			assert.Equal(t, 9090, facade.Get("server.port"))
+		}))
+	})
+
+	t.Run("should not shadow lower priority layers with flag defaults", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathBoot, true)
+		_ = config.Defaults.Set("server.port", 80)
+		_ = config.Defaults.Set("app.name", "default name")
+		_ = config.Defaults.Set(config.PathSources, flam.Bag{
+			"my_source": flam.Bag{
+				"driver":   config.SourceDriverFlags,
+				"priority": 100,
+				"args":     []string{"-name", "flag name"},
+				"mappings": mappings,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			assert.Equal(t, 80, facade.Get("server.port"))
+			assert.Equal(t, "flag name", facade.Get("app.name"))
+		}))
+	})
+}
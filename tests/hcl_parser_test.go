@@ -0,0 +1,255 @@
+package tests
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/dig"
+
+	flam "github.com/happyhippyhippo/flam"
+	config "github.com/happyhippyhippo/flam-config"
+	mocks "github.com/happyhippyhippo/flam-config/tests/mocks"
+	filesystem "github.com/happyhippyhippo/flam-filesystem"
+	flamTime "github.com/happyhippyhippo/flam-time"
+)
+
+func Test_hclParser(t *testing.T) {
+	t.Run("should return reader error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverHcl,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		expectedErr := errors.New("reader error")
+		reader := mocks.NewReadCloser(ctrl)
+		reader.EXPECT().Read(gomock.Any()).Return(0, expectedErr).Times(1)
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(reader)
+			assert.Nil(t, got)
+			assert.ErrorIs(t, e, expectedErr)
+		}))
+	})
+
+	t.Run("should return parsing error", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverHcl,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(strings.NewReader("field = "))
+			assert.Nil(t, got)
+			assert.ErrorContains(t, e, "Missing expression")
+		}))
+	})
+
+	t.Run("should reject non-literal expressions", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverHcl,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		scenarios := []string{
+			"field = 1 + 2",
+			"field = upper(\"value\")",
+			"field = var.name",
+			"field = \"${var.name}\"",
+			"block { field = true ? 1 : 2 }",
+		}
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			for _, scenario := range scenarios {
+				got, e := parser.Parse(strings.NewReader(scenario))
+				assert.Nil(t, got, scenario)
+				assert.ErrorIs(t, e, config.ErrHclInvalidExpression, scenario)
+			}
+		}))
+	})
+
+	t.Run("should reject blocks conflicting with existing values", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverHcl,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		scenarios := []string{
+			"service { a = 1 }\nservice { a = 2 }\nservice \"x\" { a = 3 }",
+			"service = 1\nservice \"x\" { a = 3 }",
+			"service \"x\" { a = 1 }\nservice \"x\" { a = 2 }\nservice \"x\" \"y\" { a = 3 }",
+		}
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			for _, scenario := range scenarios {
+				got, e := parser.Parse(strings.NewReader(scenario))
+				assert.Nil(t, got, scenario)
+				assert.ErrorIs(t, e, config.ErrHclBlockConflict, scenario)
+			}
+		}))
+	})
+
+	t.Run("should parse hcl content", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverHcl,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		data := `
+Name    = "value"
+port    = 8080
+ratio   = -1.5
+enabled = true
+hosts   = ["first", "second"]
+limits  = { cpu = 2, memory = "1Gi" }
+
+database {
+  host = "localhost"
+}
+
+service "api" "v1" {
+  port = 80
+}
+
+service "web" {
+  port = 443
+}
+
+rule {
+  allow = "a"
+}
+
+rule {
+  allow = "b"
+}
+`
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(strings.NewReader(data))
+			require.NoError(t, e)
+
+			assert.Equal(t, flam.Bag{
+				"name":     "value",
+				"port":     8080,
+				"ratio":    -1.5,
+				"enabled":  true,
+				"hosts":    []any{"first", "second"},
+				"limits":   flam.Bag{"cpu": 2, "memory": "1Gi"},
+				"database": flam.Bag{"host": "localhost"},
+				"service": flam.Bag{
+					"api": flam.Bag{"v1": flam.Bag{"port": 80}},
+					"web": flam.Bag{"port": 443},
+				},
+				"rule": []any{
+					flam.Bag{"allow": "a"},
+					flam.Bag{"allow": "b"},
+				},
+			}, got)
+		}))
+	})
+
+	t.Run("should interpolate configured variables", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverHcl,
+				"variables": flam.Bag{
+					"env":   "prod",
+					"port":  8080,
+					"hosts": []any{"first", "second"},
+				},
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		data := `
+name  = "service-${var.env}"
+port  = var.port
+hosts = var.hosts
+`
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(strings.NewReader(data))
+			require.NoError(t, e)
+
+			assert.Equal(t, flam.Bag{
+				"name":  "service-prod",
+				"port":  8080,
+				"hosts": []any{"first", "second"},
+			}, got)
+
+			got, e = parser.Parse(strings.NewReader("field = var.unknown"))
+			assert.Nil(t, got)
+			assert.ErrorContains(t, e, "Unsupported attribute")
+		}))
+	})
+}
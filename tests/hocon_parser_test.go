@@ -0,0 +1,362 @@
+package tests
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/dig"
+
+	flam "github.com/happyhippyhippo/flam"
+	config "github.com/happyhippyhippo/flam-config"
+	mocks "github.com/happyhippyhippo/flam-config/tests/mocks"
+	filesystem "github.com/happyhippyhippo/flam-filesystem"
+	flamTime "github.com/happyhippyhippo/flam-time"
+)
+
+func Test_hoconParser(t *testing.T) {
+	t.Run("should return reader error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverHocon,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		expectedErr := errors.New("reader error")
+		reader := mocks.NewReadCloser(ctrl)
+		reader.EXPECT().Read(gomock.Any()).Return(0, expectedErr).Times(1)
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(reader)
+			assert.Nil(t, got)
+			assert.ErrorIs(t, e, expectedErr)
+		}))
+	})
+
+	t.Run("should return parsing errors", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverHocon,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		scenarios := []struct {
+			data     string
+			expected error
+		}{
+			{data: "a {", expected: config.ErrHoconSyntax},
+			{data: "a = [1, 2", expected: config.ErrHoconSyntax},
+			{data: "a = \"unterminated", expected: config.ErrHoconSyntax},
+			{data: "a = \"\"\"unterminated", expected: config.ErrHoconSyntax},
+			{data: "a ! 1", expected: config.ErrHoconSyntax},
+			{data: "a = ", expected: config.ErrHoconSyntax},
+			{data: "a = 1 }", expected: config.ErrHoconSyntax},
+			{data: "{ a = 1 } b", expected: config.ErrHoconSyntax},
+			{data: "a..b = 1", expected: config.ErrHoconSyntax},
+			{data: "a = ${b", expected: config.ErrHoconSyntax},
+			{data: "include url(\"http://host\")", expected: config.ErrHoconSyntax},
+			{data: "a = ${HOCON_MISSING_VARIABLE}", expected: config.ErrHoconSubstitutionNotFound},
+			{data: "a = ${b}\nb = ${a}", expected: config.ErrHoconSubstitutionCycle},
+			{data: "a = [1] {b = 1}", expected: config.ErrHoconInvalidConcatenation},
+			{data: "include \"other.conf\"", expected: config.ErrHoconDiskNotFound},
+		}
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			for _, scenario := range scenarios {
+				got, e := parser.Parse(strings.NewReader(scenario.data))
+				assert.Nil(t, got, scenario.data)
+				assert.ErrorIs(t, e, scenario.expected, scenario.data)
+			}
+		}))
+	})
+
+	t.Run("should parse hocon content", func(t *testing.T) {
+		require.NoError(t, os.Setenv("HOCON_ENV_FIELD", "env_value"))
+		defer func() { _ = os.Unsetenv("HOCON_ENV_FIELD") }()
+
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverHocon,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		data := `
+# comment
+// another comment
+app {
+  name = "my app"
+  version: 1.5
+  enabled = true
+  nothing = null
+}
+app.port = 8080
+app { host = localhost }
+"quoted.key" = value
+
+defaults { timeout = 10s, retries = 3 }
+service = ${defaults} { retries = 5 }
+
+url = "http://"${app.host}":"${app.port}/path
+greeting = hello world
+home = ${?HOCON_ENV_FIELD}
+optional = ${?HOCON_MISSING_VARIABLE}
+
+path = [a]
+path = ${path} [b]
+path += c
+
+timeouts {
+  short = 500 ms
+  long = 2 minutes
+}
+sizes {
+  small = 512K
+  large = 1.5 GB
+}
+text = """multi
+line "text" """
+list = [
+  1
+  2,
+  "three"
+]
+`
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(strings.NewReader(data))
+			require.NoError(t, e)
+
+			assert.Equal(t, flam.Bag{
+				"app": flam.Bag{
+					"name":    "my app",
+					"version": 1.5,
+					"enabled": true,
+					"nothing": nil,
+					"port":    8080,
+					"host":    "localhost",
+				},
+				"quoted.key": "value",
+				"defaults":   flam.Bag{"timeout": "10s", "retries": 3},
+				"service":    flam.Bag{"timeout": "10s", "retries": 5},
+				"url":        "http://localhost:8080/path",
+				"greeting":   "hello world",
+				"home":       "env_value",
+				"path":       []any{"a", "b", "c"},
+				"timeouts": flam.Bag{
+					"short": "500 ms",
+					"long":  "2 minutes",
+				},
+				"sizes": flam.Bag{
+					"small": "512K",
+					"large": "1.5 GB",
+				},
+				"text": "multi\nline \"text\" ",
+				"list": []any{1, 2, "three"},
+			}, got)
+		}))
+	})
+
+	t.Run("should convert duration and size units when enabled", func(t *testing.T) {
+		scenarios := []struct {
+			test     string
+			units    bool
+			expected flam.Bag
+		}{
+			{
+				test: "disabled",
+				expected: flam.Bag{
+					"timeout": "10s",
+					"short":   "500 ms",
+					"long":    "2 minutes",
+					"small":   "512K",
+					"large":   "1.5 GB",
+					"ver":     "2d",
+					"tier":    "1g",
+					"quoted":  "10s",
+				},
+			},
+			{
+				test:  "enabled",
+				units: true,
+				expected: flam.Bag{
+					"timeout": 10 * time.Second,
+					"short":   500 * time.Millisecond,
+					"long":    2 * time.Minute,
+					"small":   512 * 1024,
+					"large":   1500000000,
+					"ver":     48 * time.Hour,
+					"tier":    1 << 30,
+					"quoted":  "10s",
+				},
+			},
+		}
+
+		data := `
+timeout = 10s
+short = 500 ms
+long = 2 minutes
+small = 512K
+large = 1.5 GB
+ver = 2d
+tier = 1g
+quoted = "10s"
+`
+
+		for _, scenario := range scenarios {
+			t.Run(scenario.test, func(t *testing.T) {
+				config.Defaults = flam.Bag{}
+				_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+					"my_parser": flam.Bag{
+						"driver": config.ParserDriverHocon,
+						"units":  scenario.units,
+					}})
+				defer func() { config.Defaults = flam.Bag{} }()
+
+				container := dig.New()
+				require.NoError(t, flamTime.NewProvider().Register(container))
+				require.NoError(t, filesystem.NewProvider().Register(container))
+				require.NoError(t, config.NewProvider().Register(container))
+				require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+				assert.NoError(t, container.Invoke(func(facade config.Facade) {
+					parser, e := facade.GetParser("my_parser")
+					require.NoError(t, e)
+
+					got, e := parser.Parse(strings.NewReader(data))
+					require.NoError(t, e)
+					assert.Equal(t, scenario.expected, got)
+				}))
+			})
+		}
+	})
+
+	t.Run("should resolve includes through the source disk", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathBoot, true)
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverHocon,
+			}})
+		_ = config.Defaults.Set(config.PathSources, flam.Bag{
+			"my_source": flam.Bag{
+				"driver": config.SourceDriverFile,
+				"disk":   "my_disk",
+				"parser": "my_parser",
+				"path":   "/config/application.conf",
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		disk := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(disk, "/config/application.conf", []byte(`
+include "base.conf"
+include file("missing.conf")
+db { port = 5433 }
+db.url = "postgres://"${db.host}":"${db.port}
+`), 0o644))
+		require.NoError(t, afero.WriteFile(disk, "/config/base.conf", []byte(`
+include required(file("shared/db.conf"))
+`), 0o644))
+		require.NoError(t, afero.WriteFile(disk, "/config/shared/db.conf", []byte(`db { host = localhost, port = 5432 }`), 0o644))
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, container.Invoke(func(facade filesystem.Facade) error {
+			return facade.AddDisk("my_disk", disk)
+		}))
+
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			assert.Equal(t, "localhost", facade.Get("db.host"))
+			assert.Equal(t, 5433, facade.Get("db.port"))
+			assert.Equal(t, "postgres://localhost:5433", facade.Get("db.url"))
+		}))
+	})
+
+	t.Run("should return include errors", func(t *testing.T) {
+		scenarios := []struct {
+			name     string
+			content  string
+			expected error
+		}{
+			{name: "required", content: "include required(\"missing.conf\")", expected: os.ErrNotExist},
+			{name: "cycle", content: "include \"application.conf\"", expected: config.ErrHoconIncludeCycle},
+		}
+
+		for _, scenario := range scenarios {
+			t.Run(scenario.name, func(t *testing.T) {
+				config.Defaults = flam.Bag{}
+				_ = config.Defaults.Set(config.PathBoot, true)
+				_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+					"my_parser": flam.Bag{
+						"driver": config.ParserDriverHocon,
+					}})
+				_ = config.Defaults.Set(config.PathSources, flam.Bag{
+					"my_source": flam.Bag{
+						"driver": config.SourceDriverFile,
+						"disk":   "my_disk",
+						"parser": "my_parser",
+						"path":   "/config/application.conf",
+					}})
+				defer func() { config.Defaults = flam.Bag{} }()
+
+				disk := afero.NewMemMapFs()
+				require.NoError(t, afero.WriteFile(disk, "/config/application.conf", []byte(scenario.content), 0o644))
+
+				container := dig.New()
+				require.NoError(t, flamTime.NewProvider().Register(container))
+				require.NoError(t, filesystem.NewProvider().Register(container))
+				require.NoError(t, config.NewProvider().Register(container))
+				require.NoError(t, container.Invoke(func(facade filesystem.Facade) error {
+					return facade.AddDisk("my_disk", disk)
+				}))
+
+				assert.ErrorIs(
+					t,
+					config.NewProvider().(flam.BootableProvider).Boot(container),
+					scenario.expected)
+			})
+		}
+	})
+}
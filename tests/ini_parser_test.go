@@ -0,0 +1,143 @@
+package tests
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/dig"
+
+	flam "github.com/happyhippyhippo/flam"
+	config "github.com/happyhippyhippo/flam-config"
+	mocks "github.com/happyhippyhippo/flam-config/tests/mocks"
+	filesystem "github.com/happyhippyhippo/flam-filesystem"
+	flamTime "github.com/happyhippyhippo/flam-time"
+)
+
+func Test_iniParser(t *testing.T) {
+	t.Run("should return reader error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverIni,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		expectedErr := errors.New("reader error")
+		reader := mocks.NewReadCloser(ctrl)
+		reader.EXPECT().Read(gomock.Any()).Return(0, expectedErr).Times(1)
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(reader)
+			assert.Nil(t, got)
+			assert.ErrorIs(t, e, expectedErr)
+		}))
+	})
+
+	t.Run("should return invalid line errors", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverIni,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		scenarios := []string{
+			"[section",
+			"[]",
+			"no separator",
+			"= value",
+			"key = trailing \\\\\\",
+		}
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			for _, scenario := range scenarios {
+				got, e := parser.Parse(strings.NewReader(scenario))
+				assert.Nil(t, got, scenario)
+				assert.ErrorIs(t, e, config.ErrIniInvalidLine, scenario)
+			}
+		}))
+	})
+
+	t.Run("should parse ini content", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverIni,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		data := `
+; comment line
+# another comment line \
+Root = value ; inline comment
+path.to.key: nested
+
+[Section]
+multi = first \
+        second
+escaped = a\;b\#c\\d\te
+quoted = "  spaced ; value  "
+commented = "abc" ; inline comment
+hashed = 'a # b' # inline comment
+escaped_quote = "a \" b" ; inline comment
+unbalanced = "abc" def ; inline comment
+
+[section.sub]
+key = sub value
+`
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(strings.NewReader(data))
+			require.NoError(t, e)
+
+			assert.Equal(t, flam.Bag{
+				"root": "value",
+				"path": flam.Bag{"to": flam.Bag{"key": "nested"}},
+				"section": flam.Bag{
+					"multi":         "first second",
+					"escaped":       "a;b#c\\d\te",
+					"quoted":        "  spaced ; value  ",
+					"commented":     "abc",
+					"hashed":        "a # b",
+					"escaped_quote": "a \" b",
+					"unbalanced":    "\"abc\" def",
+					"sub":           flam.Bag{"key": "sub value"},
+				},
+			}, got)
+		}))
+	})
+}
@@ -0,0 +1,245 @@
+package tests
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/dig"
+
+	flam "github.com/happyhippyhippo/flam"
+	config "github.com/happyhippyhippo/flam-config"
+	mocks "github.com/happyhippyhippo/flam-config/tests/mocks"
+	filesystem "github.com/happyhippyhippo/flam-filesystem"
+	flamTime "github.com/happyhippyhippo/flam-time"
+)
+
+func Test_json5Parser(t *testing.T) {
+	t.Run("should return reader error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverJson5,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		expectedErr := errors.New("reader error")
+		reader := mocks.NewReadCloser(ctrl)
+		reader.EXPECT().Read(gomock.Any()).Return(0, expectedErr).Times(1)
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(reader)
+			assert.Nil(t, got)
+			assert.ErrorIs(t, e, expectedErr)
+		}))
+	})
+
+	t.Run("should return invalid token errors", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverJson5,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		scenarios := []string{
+			"{field: 'unterminated}",
+			"{field: 'broken\nstring'}",
+			"{field: 1} /* unterminated",
+			"{field: 1} / 2",
+			"{field: value}",
+			"{field: 0xZZ}",
+			"{field: '\\u12'}",
+			"{field: -}",
+			"[,]",
+			"{,}",
+			"{field: [1,,2]}",
+			"{field: 1,, other: 2}",
+			"{field: , other: 2}",
+		}
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			for _, scenario := range scenarios {
+				got, e := parser.Parse(strings.NewReader(scenario))
+				assert.Nil(t, got, scenario)
+				assert.ErrorIs(t, e, config.ErrJson5InvalidToken, scenario)
+			}
+		}))
+	})
+
+	t.Run("should return json parsing error", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverJson5,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(strings.NewReader("{field: 1"))
+			assert.Nil(t, got)
+			assert.ErrorContains(t, e, "unexpected end of JSON input")
+		}))
+	})
+
+	t.Run("should parse json5 content", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverJson5,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		data := `// leading comment
+{
+  /* block
+     comment */
+  Unquoted: 'single "quoted"',
+  "quoted": "double 'quoted'", // trailing comment
+  $dollar_key: true,
+  escaped: 'line\
+ continued\ttab\x41B',
+  numbers: [123, +1, -2, .5, 5., 0x1F, 1.5e3,],
+  nested: {
+    "null": null,
+    list: [
+      {name: 'first'},
+      {name: 'second',},
+    ],
+  },
+}
+`
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(strings.NewReader(data))
+			require.NoError(t, e)
+
+			assert.Equal(t, flam.Bag{
+				"unquoted":    "single \"quoted\"",
+				"quoted":      "double 'quoted'",
+				"$dollar_key": true,
+				"escaped":     "line continued\ttabAB",
+				"numbers":     []any{123, 1, -2, 0.5, 5, 31, 1500},
+				"nested": flam.Bag{
+					"null": nil,
+					"list": []any{
+						flam.Bag{"name": "first"},
+						flam.Bag{"name": "second"},
+					},
+				},
+			}, got)
+		}))
+	})
+
+	t.Run("should decode control character escapes", func(t *testing.T) {
+		scenarios := []struct {
+			test     string
+			data     string
+			expected string
+		}{
+			{
+				test:     "vertical tab",
+				data:     `{a: 'x\vy'}`,
+				expected: "x\vy",
+			},
+			{
+				test:     "null character",
+				data:     `{a: 'x\0y'}`,
+				expected: "x\x00y",
+			},
+			{
+				test:     "hexadecimal control character",
+				data:     `{a: 'x\x01y\x1f'}`,
+				expected: "x\x01y\x1f",
+			},
+			{
+				test:     "unicode control character",
+				data:     `{a: "x\u0007y"}`,
+				expected: "x\ay",
+			},
+			{
+				test:     "surrogate pair",
+				data:     `{a: '\uD83D\uDE00'}`,
+				expected: "\U0001F600",
+			},
+			{
+				test:     "lone surrogate",
+				data:     `{a: '\uD83Dx'}`,
+				expected: "\uFFFDx",
+			},
+			{
+				test:     "html characters",
+				data:     `{a: '<a & b>'}`,
+				expected: "<a & b>",
+			},
+		}
+
+		for _, scenario := range scenarios {
+			t.Run(scenario.test, func(t *testing.T) {
+				config.Defaults = flam.Bag{}
+				_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+					"my_parser": flam.Bag{
+						"driver": config.ParserDriverJson5,
+					}})
+				defer func() { config.Defaults = flam.Bag{} }()
+
+				container := dig.New()
+				require.NoError(t, flamTime.NewProvider().Register(container))
+				require.NoError(t, filesystem.NewProvider().Register(container))
+				require.NoError(t, config.NewProvider().Register(container))
+				require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+				assert.NoError(t, container.Invoke(func(facade config.Facade) {
+					parser, e := facade.GetParser("my_parser")
+					require.NoError(t, e)
+
+					got, e := parser.Parse(strings.NewReader(scenario.data))
+					require.NoError(t, e)
+					assert.Equal(t, flam.Bag{"a": scenario.expected}, got)
+				}))
+			})
+		}
+	})
+}
@@ -0,0 +1,159 @@
+package tests
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/dig"
+
+	flam "github.com/happyhippyhippo/flam"
+	config "github.com/happyhippyhippo/flam-config"
+	filesystem "github.com/happyhippyhippo/flam-filesystem"
+	flamTime "github.com/happyhippyhippo/flam-time"
+)
+
+func Test_jsonParser(t *testing.T) {
+	t.Run("should honour the parser options", func(t *testing.T) {
+		scenarios := []struct {
+			test     string
+			options  flam.Bag
+			data     string
+			expected flam.Bag
+			err      error
+			contains string
+		}{
+			{
+				test:     "duplicate keys are allowed by default",
+				options:  flam.Bag{},
+				data:     `{"field": 1, "field": 2}`,
+				expected: flam.Bag{"field": 2},
+			},
+			{
+				test:    "duplicate keys are rejected",
+				options: flam.Bag{"disallow_duplicate_keys": true},
+				data:    `{"node": [{"field": 1, "field": 2}]}`,
+				err:     config.ErrParserDuplicateKey,
+			},
+			{
+				test:    "duplicate keys differing only in case are rejected",
+				options: flam.Bag{"disallow_duplicate_keys": true},
+				data:    `{"node": {"Field": 1, "field": 2}}`,
+				err:     config.ErrParserDuplicateKey,
+			},
+			{
+				test:     "distinct keys are accepted when duplicates are rejected",
+				options:  flam.Bag{"disallow_duplicate_keys": true},
+				data:     `{"node": {"field": 1}, "other": [{"field": 2}]}`,
+				expected: flam.Bag{"node": flam.Bag{"field": 1}, "other": []any{flam.Bag{"field": 2}}},
+			},
+			{
+				test:     "numbers are preserved",
+				options:  flam.Bag{"use_number": true},
+				data:     `{"big": 12345678901234567890, "ratio": 0.10}`,
+				expected: flam.Bag{"big": json.Number("12345678901234567890"), "ratio": json.Number("0.10")},
+			},
+			{
+				test:     "trailing data is rejected when preserving numbers",
+				options:  flam.Bag{"use_number": true},
+				data:     `{"field": 1} {}`,
+				contains: "after top-level value",
+			},
+			{
+				test:     "document within the maximum size",
+				options:  flam.Bag{"max_size": 15},
+				data:     `{"field": 123}`,
+				expected: flam.Bag{"field": 123},
+			},
+			{
+				test:    "document exceeding the maximum size",
+				options: flam.Bag{"max_size": 10},
+				data:    `{"field": 123}`,
+				err:     config.ErrParserDocumentTooLarge,
+			},
+			{
+				test:     "document within the maximum depth",
+				options:  flam.Bag{"max_depth": 2},
+				data:     `{"node": {"field": 1}, "list": [1]}`,
+				expected: flam.Bag{"node": flam.Bag{"field": 1}, "list": []any{1}},
+			},
+			{
+				test:    "document exceeding the maximum depth",
+				options: flam.Bag{"max_depth": 2},
+				data:    `{"list": [{"field": 1}]}`,
+				err:     config.ErrParserMaxDepthExceeded,
+			},
+			{
+				test:     "known fields in strict mode",
+				options:  flam.Bag{"strict": true, "fields": []any{"db.host", "db.port", "Log"}},
+				data:     `{"db": {"host": "localhost"}, "log": {"level": "debug"}}`,
+				expected: flam.Bag{"db": flam.Bag{"host": "localhost"}, "log": flam.Bag{"level": "debug"}},
+			},
+			{
+				test:    "unknown fields in strict mode",
+				options: flam.Bag{"strict": true, "fields": []string{"db.host"}},
+				data:    `{"db": {"host": "localhost", "user": "admin"}}`,
+				err:     config.ErrParserUnknownField,
+			},
+		}
+
+		for _, scenario := range scenarios {
+			t.Run(scenario.test, func(t *testing.T) {
+				parserConfig := flam.Bag{"driver": config.ParserDriverJson}
+				parserConfig.Merge(scenario.options)
+
+				config.Defaults = flam.Bag{}
+				_ = config.Defaults.Set(config.PathParsers, flam.Bag{"my_parser": parserConfig})
+				defer func() { config.Defaults = flam.Bag{} }()
+
+				container := dig.New()
+				require.NoError(t, flamTime.NewProvider().Register(container))
+				require.NoError(t, filesystem.NewProvider().Register(container))
+				require.NoError(t, config.NewProvider().Register(container))
+				require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+				assert.NoError(t, container.Invoke(func(facade config.Facade) {
+					parser, e := facade.GetParser("my_parser")
+					require.NoError(t, e)
+
+					got, e := parser.Parse(strings.NewReader(scenario.data))
+					switch {
+					case scenario.err != nil:
+						assert.Nil(t, got)
+						assert.ErrorIs(t, e, scenario.err)
+					case scenario.contains != "":
+						assert.Nil(t, got)
+						assert.ErrorContains(t, e, scenario.contains)
+					default:
+						require.NoError(t, e)
+						assert.Equal(t, scenario.expected, got)
+					}
+				}))
+			})
+		}
+	})
+
+	t.Run("should return strict mode without fields error", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverJson,
+				"strict": true,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			assert.Nil(t, parser)
+			assert.ErrorIs(t, e, config.ErrParserStrictWithoutFields)
+		}))
+	})
+}
@@ -0,0 +1,207 @@
+package tests
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/dig"
+
+	flam "github.com/happyhippyhippo/flam"
+	config "github.com/happyhippyhippo/flam-config"
+	mocks "github.com/happyhippyhippo/flam-config/tests/mocks"
+	filesystem "github.com/happyhippyhippo/flam-filesystem"
+	flamTime "github.com/happyhippyhippo/flam-time"
+)
+
+func Test_jsonnetParser(t *testing.T) {
+	t.Run("should return reader error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverJsonnet,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		expectedErr := errors.New("reader error")
+		reader := mocks.NewReadCloser(ctrl)
+		reader.EXPECT().Read(gomock.Any()).Return(0, expectedErr).Times(1)
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(reader)
+			assert.Nil(t, got)
+			assert.ErrorIs(t, e, expectedErr)
+		}))
+	})
+
+	t.Run("should return evaluation errors", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverJsonnet,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(strings.NewReader("{ field: error 'broken' }"))
+			assert.Nil(t, got)
+			assert.ErrorContains(t, e, "broken")
+
+			got, e = parser.Parse(strings.NewReader("[1, 2]"))
+			assert.Nil(t, got)
+			assert.Error(t, e)
+
+			got, e = parser.Parse(strings.NewReader("import 'lib.libsonnet'"))
+			assert.Nil(t, got)
+			assert.ErrorContains(t, e, config.ErrJsonnetDiskNotFound.Error())
+		}))
+	})
+
+	t.Run("should evaluate with ext vars and top-level args", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverJsonnet,
+				"ext_vars": flam.Bag{
+					"env":   "prod",
+					"ports": []any{80, 443},
+				},
+				"tla_args": flam.Bag{
+					"replicas": 3,
+					"name":     "api",
+				},
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		data := `
+function(name, replicas) {
+  Service: name + '-' + std.extVar('env'),
+  replicas: replicas * 2,
+  ports: std.extVar('ports'),
+  ratio: 0.5,
+}
+`
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(strings.NewReader(data))
+			require.NoError(t, e)
+
+			assert.Equal(t, flam.Bag{
+				"service":  "api-prod",
+				"replicas": 6,
+				"ports":    []any{80, 443},
+				"ratio":    0.5,
+			}, got)
+		}))
+	})
+
+	t.Run("should resolve imports through the source disk", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathBoot, true)
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver":       config.ParserDriverJsonnet,
+				"import_paths": []any{"/vendor"},
+			}})
+		_ = config.Defaults.Set(config.PathSources, flam.Bag{
+			"my_source": flam.Bag{
+				"driver": config.SourceDriverFile,
+				"disk":   "my_disk",
+				"parser": "my_parser",
+				"path":   "/config/main.jsonnet",
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		disk := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(disk, "/config/main.jsonnet", []byte(`
+local local_lib = import 'local.libsonnet';
+local vendor_lib = import 'vendor.libsonnet';
+{ own: local_lib.value, vendor: vendor_lib.value, again: (import 'local.libsonnet').value }
+`), 0o644))
+		require.NoError(t, afero.WriteFile(disk, "/config/local.libsonnet", []byte(`{ value: 'local' }`), 0o644))
+		require.NoError(t, afero.WriteFile(disk, "/vendor/vendor.libsonnet", []byte(`{ value: 'vendor' }`), 0o644))
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, container.Invoke(func(facade filesystem.Facade) error {
+			return facade.AddDisk("my_disk", disk)
+		}))
+
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			assert.Equal(t, "local", facade.Get("own"))
+			assert.Equal(t, "vendor", facade.Get("vendor"))
+			assert.Equal(t, "local", facade.Get("again"))
+		}))
+	})
+
+	t.Run("should return import not found error", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathBoot, true)
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverJsonnet,
+			}})
+		_ = config.Defaults.Set(config.PathSources, flam.Bag{
+			"my_source": flam.Bag{
+				"driver": config.SourceDriverFile,
+				"disk":   "my_disk",
+				"parser": "my_parser",
+				"path":   "/config/main.jsonnet",
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		disk := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(disk, "/config/main.jsonnet", []byte(`import 'missing.libsonnet'`), 0o644))
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, container.Invoke(func(facade filesystem.Facade) error {
+			return facade.AddDisk("my_disk", disk)
+		}))
+
+		assert.ErrorContains(
+			t,
+			config.NewProvider().(flam.BootableProvider).Boot(container),
+			config.ErrJsonnetImportNotFound.Error())
+	})
+}
@@ -0,0 +1,263 @@
+package tests
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/dig"
+
+	flam "github.com/happyhippyhippo/flam"
+	config "github.com/happyhippyhippo/flam-config"
+	mocks "github.com/happyhippyhippo/flam-config/tests/mocks"
+	filesystem "github.com/happyhippyhippo/flam-filesystem"
+	flamTime "github.com/happyhippyhippo/flam-time"
+)
+
+func testKeyring(primary string) string {
+	return fmt.Sprintf(`{"primary": %q, "keys": {"k1": %q, "k2": %q}}`,
+		primary,
+		base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef")),
+		base64.StdEncoding.EncodeToString([]byte("fedcba9876543210fedcba9876543210")))
+}
+
+func Test_EncryptSecret(t *testing.T) {
+	t.Run("should return keyring errors", func(t *testing.T) {
+		scenarios := []struct {
+			test    string
+			keyring string
+			err     error
+		}{
+			{
+				test:    "invalid keyring",
+				keyring: "{",
+			},
+			{
+				test:    "invalid key encoding",
+				keyring: `{"primary": "k1", "keys": {"k1": "!"}}`,
+				err:     config.ErrSecretInvalidKey,
+			},
+			{
+				test:    "invalid key size",
+				keyring: `{"primary": "k1", "keys": {"k1": "a2V5"}}`,
+				err:     config.ErrSecretInvalidKey,
+			},
+			{
+				test:    "missing primary key",
+				keyring: testKeyring("k3"),
+				err:     config.ErrSecretKeyNotFound,
+			},
+		}
+
+		for _, scenario := range scenarios {
+			t.Run(scenario.test, func(t *testing.T) {
+				got, e := config.EncryptSecret(strings.NewReader(scenario.keyring), "value")
+				assert.Empty(t, got)
+				assert.Error(t, e)
+				if scenario.err != nil {
+					assert.ErrorIs(t, e, scenario.err)
+				}
+			})
+		}
+	})
+
+	t.Run("should encrypt with the primary key", func(t *testing.T) {
+		got, e := config.EncryptSecret(strings.NewReader(testKeyring("k2")), "value")
+		require.NoError(t, e)
+		assert.True(t, strings.HasPrefix(got, "enc:v1:k2:"))
+		assert.NotContains(t, got, "value")
+
+		other, e := config.EncryptSecret(strings.NewReader(testKeyring("k2")), "value")
+		require.NoError(t, e)
+		assert.NotEqual(t, got, other)
+	})
+}
+
+func Test_Keyring(t *testing.T) {
+	encrypt := func(t *testing.T, primary, value string) string {
+		encrypted, e := config.EncryptSecret(strings.NewReader(testKeyring(primary)), value)
+		require.NoError(t, e)
+		return encrypted
+	}
+
+	boot := func(t *testing.T, defaults flam.Bag, keyring bool) (*dig.Container, error) {
+		config.Defaults = defaults
+		if keyring {
+			_ = config.Defaults.Set(config.PathSecretsDisk, "my_disk")
+			_ = config.Defaults.Set(config.PathSecretsKeyring, "/keyring.json")
+		}
+
+		disk := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(disk, "/keyring.json", []byte(testKeyring("k2")), 0o600))
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, container.Invoke(func(facade filesystem.Facade) error {
+			return facade.AddDisk("my_disk", disk)
+		}))
+
+		return container, config.NewProvider().(flam.BootableProvider).Boot(container)
+	}
+
+	t.Run("should decrypt values with every keyring key", func(t *testing.T) {
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container, e := boot(t, flam.Bag{
+			"db": flam.Bag{
+				"user":     "admin",
+				"password": encrypt(t, "k2", "current secret"),
+				"legacy":   encrypt(t, "k1", "rotated secret"),
+				"hosts":    []any{encrypt(t, "k1", "host a"), "host b"},
+			},
+		}, true)
+		require.NoError(t, e)
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			assert.Equal(t, "admin", facade.Get("db.user"))
+			assert.Equal(t, "current secret", facade.Get("db.password"))
+			assert.Equal(t, "rotated secret", facade.Get("db.legacy"))
+			assert.Equal(t, []any{"host a", "host b"}, facade.Get("db.hosts"))
+		}))
+	})
+
+	t.Run("should keep values untouched without a keyring", func(t *testing.T) {
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		encrypted := encrypt(t, "k2", "secret")
+		container, e := boot(t, flam.Bag{"password": encrypted}, false)
+		require.NoError(t, e)
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			assert.Equal(t, encrypted, facade.Get("password"))
+		}))
+	})
+
+	t.Run("should return decryption errors on boot", func(t *testing.T) {
+		encrypted := encrypt(t, "k2", "secret")
+
+		scenarios := []struct {
+			test  string
+			value string
+			err   error
+		}{
+			{
+				test:  "unknown key id",
+				value: strings.Replace(encrypted, "enc:v1:k2:", "enc:v1:k3:", 1),
+				err:   config.ErrSecretKeyNotFound,
+			},
+			{
+				test:  "wrong key id",
+				value: strings.Replace(encrypted, "enc:v1:k2:", "enc:v1:k1:", 1),
+				err:   config.ErrSecretDecryption,
+			},
+			{
+				test:  "invalid encoding",
+				value: "enc:v1:k2:!",
+				err:   config.ErrSecretInvalidValue,
+			},
+			{
+				test:  "missing key id",
+				value: "enc:v1:value",
+				err:   config.ErrSecretInvalidValue,
+			},
+			{
+				test:  "truncated value",
+				value: "enc:v1:k2:YWJj",
+				err:   config.ErrSecretInvalidValue,
+			},
+		}
+
+		for _, scenario := range scenarios {
+			t.Run(scenario.test, func(t *testing.T) {
+				defer func() { config.Defaults = flam.Bag{} }()
+
+				_, e := boot(t, flam.Bag{"password": scenario.value}, true)
+				assert.ErrorIs(t, e, scenario.err)
+			})
+		}
+	})
+
+	t.Run("should return keyring loading error", func(t *testing.T) {
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		_, e := boot(t, flam.Bag{
+			"flam": flam.Bag{"config": flam.Bag{"secrets": flam.Bag{"keyring": "/missing.json"}}},
+		}, false)
+		assert.Error(t, e)
+	})
+
+	t.Run("should decrypt values set at runtime", func(t *testing.T) {
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container, e := boot(t, flam.Bag{"password": "plain"}, true)
+		require.NoError(t, e)
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			assert.NoError(t, facade.Set("password", encrypt(t, "k1", "secret")))
+			assert.Equal(t, "secret", facade.Get("password"))
+
+			assert.ErrorIs(t, facade.Set("password", "enc:v1:k3:YWJj"), config.ErrSecretKeyNotFound)
+			assert.Equal(t, "secret", facade.Get("password"))
+
+			assert.NoError(t, facade.Set("other", "value"))
+			assert.Equal(t, "value", facade.Get("other"))
+		}))
+	})
+
+	t.Run("should not register sources that fail decryption", func(t *testing.T) {
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container, e := boot(t, flam.Bag{"password": "plain"}, true)
+		require.NoError(t, e)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		source := mocks.NewSource(ctrl)
+		source.EXPECT().GetPriority().Return(0).AnyTimes()
+		source.EXPECT().Get("", flam.Bag{}).Return(flam.Bag{"password": "enc:v1:k3:YWJj"}).AnyTimes()
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			assert.ErrorIs(t, facade.AddSource("my_source", source), config.ErrSecretKeyNotFound)
+			assert.False(t, facade.HasSource("my_source"))
+			assert.Equal(t, "plain", facade.Get("password"))
+		}))
+	})
+
+	t.Run("should keep the last decrypted values of a source whose reload fails decryption", func(t *testing.T) {
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container, e := boot(t, flam.Bag{}, true)
+		require.NoError(t, e)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		source := mocks.NewObservableSource(ctrl)
+		source.EXPECT().GetPriority().Return(0).AnyTimes()
+		source.EXPECT().SetPriority(10)
+		source.EXPECT().Get("", flam.Bag{}).Return(flam.Bag{"password": encrypt(t, "k1", "secret")})
+		source.EXPECT().Get("", flam.Bag{}).Return(flam.Bag{"password": "enc:v1:k3:YWJj"}).AnyTimes()
+		source.EXPECT().Reload().Return(true, nil)
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			require.NoError(t, facade.AddSource("my_source", source))
+			assert.Equal(t, "secret", facade.Get("password"))
+
+			assert.ErrorIs(t, facade.ReloadSources(), config.ErrSecretKeyNotFound)
+			assert.Equal(t, "secret", facade.Get("password"))
+
+			assert.NoError(t, facade.Set("other", "value"))
+			assert.NoError(t, facade.SetSourcePriority("my_source", 10))
+			assert.Equal(t, "value", facade.Get("other"))
+			assert.Equal(t, "secret", facade.Get("password"))
+		}))
+	})
+}
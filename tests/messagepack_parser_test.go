@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack/v5"
+	"go.uber.org/dig"
+
+	flam "github.com/happyhippyhippo/flam"
+	config "github.com/happyhippyhippo/flam-config"
+	mocks "github.com/happyhippyhippo/flam-config/tests/mocks"
+	filesystem "github.com/happyhippyhippo/flam-filesystem"
+	flamTime "github.com/happyhippyhippo/flam-time"
+)
+
+func Test_messagePackParser(t *testing.T) {
+	config.Defaults = flam.Bag{}
+	_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+		"my_parser": flam.Bag{
+			"driver": config.ParserDriverMessagePack,
+		}})
+	defer func() { config.Defaults = flam.Bag{} }()
+
+	container := dig.New()
+	require.NoError(t, flamTime.NewProvider().Register(container))
+	require.NoError(t, filesystem.NewProvider().Register(container))
+	require.NoError(t, config.NewProvider().Register(container))
+	require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+	t.Run("should return reader error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expectedErr := errors.New("reader error")
+		reader := mocks.NewReadCloser(ctrl)
+		reader.EXPECT().Read(gomock.Any()).Return(0, expectedErr).Times(1)
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(reader)
+			assert.Nil(t, got)
+			assert.ErrorIs(t, e, expectedErr)
+		}))
+	})
+
+	t.Run("should return decoding error", func(t *testing.T) {
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(bytes.NewReader([]byte{0x81, 0xa1}))
+			assert.Nil(t, got)
+			assert.Error(t, e)
+		}))
+	})
+
+	t.Run("should return invalid root error", func(t *testing.T) {
+		data, e := msgpack.Marshal([]any{1, 2})
+		require.NoError(t, e)
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(bytes.NewReader(data))
+			assert.Nil(t, got)
+			assert.ErrorIs(t, e, config.ErrMessagePackInvalidRoot)
+		}))
+	})
+
+	t.Run("should decode the payload", func(t *testing.T) {
+		timestamp := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		buffer := &bytes.Buffer{}
+		encoder := msgpack.NewEncoder(buffer)
+		require.NoError(t, encoder.EncodeMapLen(4))
+		require.NoError(t, encoder.EncodeBytes([]byte("Binary")))
+		require.NoError(t, encoder.EncodeString("value"))
+		require.NoError(t, encoder.EncodeInt(7))
+		require.NoError(t, encoder.EncodeString("numeric key"))
+		require.NoError(t, encoder.EncodeString("Payload"))
+		require.NoError(t, encoder.EncodeBytes([]byte{0x01, 0x02}))
+		require.NoError(t, encoder.EncodeString("node"))
+		require.NoError(t, encoder.Encode(map[string]any{
+			"Updated": timestamp,
+			"count":   uint8(3),
+			"ratio":   float32(0.5),
+			"list":    []any{int8(-1), "a", nil, true},
+		}))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(bytes.NewReader(buffer.Bytes()))
+			require.NoError(t, e)
+
+			assert.Equal(t, "value", got.Get("binary"))
+			assert.Equal(t, "numeric key", got.Get("7"))
+			assert.Equal(t, []byte{0x01, 0x02}, got.Get("payload"))
+			assert.Equal(t, 3, got.Get("node.count"))
+			assert.Equal(t, 0.5, got.Get("node.ratio"))
+			assert.Equal(t, []any{-1, "a", nil, true}, got.Get("node.list"))
+			assert.True(t, timestamp.Equal(got.Get("node.updated").(time.Time)))
+		}))
+	})
+}
@@ -0,0 +1,61 @@
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	config "github.com/happyhippyhippo/flam-config"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// YamlTagResolver is a mock of ConfigYamlTagResolver interface.
+type YamlTagResolver struct {
+	ctrl     *gomock.Controller
+	recorder *YamlTagResolverRecorder
+}
+
+// YamlTagResolverRecorder is the mock recorder for YamlTagResolver.
+type YamlTagResolverRecorder struct {
+	mock *YamlTagResolver
+}
+
+// NewYamlTagResolver creates a new mock instance.
+func NewYamlTagResolver(ctrl *gomock.Controller) *YamlTagResolver {
+	mock := &YamlTagResolver{ctrl: ctrl}
+	mock.recorder = &YamlTagResolverRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *YamlTagResolver) EXPECT() *YamlTagResolverRecorder {
+	return m.recorder
+}
+
+// Resolve mocks base method.
+func (m *YamlTagResolver) Resolve(node *yaml.Node, context config.YamlTagContext) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", node, context)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *YamlTagResolverRecorder) Resolve(node, context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*YamlTagResolver)(nil).Resolve), node, context)
+}
+
+// Tag mocks base method.
+func (m *YamlTagResolver) Tag() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tag")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Tag indicates an expected call of Tag.
+func (mr *YamlTagResolverRecorder) Tag() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tag", reflect.TypeOf((*YamlTagResolver)(nil).Tag))
+}
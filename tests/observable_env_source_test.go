@@ -0,0 +1,116 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/dig"
+
+	flam "github.com/happyhippyhippo/flam"
+	config "github.com/happyhippyhippo/flam-config"
+	filesystem "github.com/happyhippyhippo/flam-filesystem"
+	flamTime "github.com/happyhippyhippo/flam-time"
+)
+
+func Test_observableEnvSource(t *testing.T) {
+	t.Run("should return invalid options error", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathBoot, true)
+		_ = config.Defaults.Set(config.PathSources, flam.Bag{
+			"my_source": flam.Bag{
+				"driver": config.SourceDriverObservableEnv,
+				"case":   "title",
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+
+		assert.ErrorIs(
+			t,
+			config.NewProvider().(flam.BootableProvider).Boot(container),
+			config.ErrEnvInvalidCase)
+	})
+
+	t.Run("should return env file stat error", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathBoot, true)
+		_ = config.Defaults.Set(config.PathSources, flam.Bag{
+			"my_source": flam.Bag{
+				"driver": config.SourceDriverObservableEnv,
+				"files":  []string{"./testdata/invalid"},
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+
+		assert.ErrorContains(
+			t,
+			config.NewProvider().(flam.BootableProvider).Boot(container),
+			"no such file or directory")
+	})
+
+	t.Run("should reload the env files when they change", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), ".env")
+		require.NoError(t, os.WriteFile(file, []byte("OBSERVABLE_FIELD=first\n"), 0o644))
+		modTime := time.Now().Add(-time.Hour)
+		require.NoError(t, os.Chtimes(file, modTime, modTime))
+
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathBoot, true)
+		_ = config.Defaults.Set(config.PathSources, flam.Bag{
+			"my_source": flam.Bag{
+				"driver":   config.SourceDriverObservableEnv,
+				"priority": 123,
+				"files":    []string{file},
+				"mappings": flam.Bag{"OBSERVABLE_FIELD": "field"},
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			got, e := facade.GetSource("my_source")
+			require.NoError(t, e)
+			source, ok := got.(config.ObservableSource)
+			require.True(t, ok)
+
+			assert.Equal(t, 123, source.GetPriority())
+			assert.Equal(t, "first", source.Get("field"))
+
+			reloaded, e := source.Reload()
+			assert.NoError(t, e)
+			assert.False(t, reloaded)
+
+			require.NoError(t, os.WriteFile(file, []byte("OBSERVABLE_FIELD=second\n"), 0o644))
+			require.NoError(t, os.Chtimes(file, time.Now(), time.Now()))
+
+			reloaded, e = source.Reload()
+			assert.NoError(t, e)
+			assert.True(t, reloaded)
+			assert.Equal(t, "second", source.Get("field"))
+
+			require.NoError(t, os.Remove(file))
+
+			reloaded, e = source.Reload()
+			assert.Error(t, e)
+			assert.False(t, reloaded)
+		}))
+
+		_, ok := os.LookupEnv("OBSERVABLE_FIELD")
+		assert.False(t, ok)
+	})
+}
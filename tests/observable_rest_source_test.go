@@ -10,6 +10,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack/v5"
 	"go.uber.org/dig"
 
 	flam "github.com/happyhippyhippo/flam"
@@ -688,6 +689,72 @@ func Test_observableRestSource(t *testing.T) {
 			assert.Equal(t, "value", got.Get("field"))
 		}))
 	})
+
+	t.Run("should correctly load the config from a messagepack response with a native timestamp", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathBoot, true)
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverMessagePack,
+			}})
+		_ = config.Defaults.Set(config.PathSources, flam.Bag{
+			"my_source": flam.Bag{
+				"driver":   config.SourceDriverObservableRest,
+				"parser":   "my_parser",
+				"uri":      "http://uri",
+				"path":     flam.Bag{"config": "config", "timestamp": "timestamp"},
+				"priority": 123,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+
+		now := time.Now()
+		timeFacade := mocks.NewTimeFacade(ctrl)
+		timeFacade.EXPECT().Now().Return(now).Times(1)
+		timeFacade.EXPECT().Unix(int64(0), int64(0)).Return(time.Unix(0, 0)).Times(1)
+		require.NoError(t, container.Provide(func() flamTime.Facade { return timeFacade }))
+
+		data, e := msgpack.Marshal(map[string]any{
+			"timestamp": now.Add(time.Hour * 24),
+			"config":    map[string]any{"field": "value"},
+		})
+		require.NoError(t, e)
+
+		reader := func(b []byte) (int, error) {
+			copy(b, data)
+			return len(data), io.EOF
+		}
+
+		body := mocks.NewReadCloser(ctrl)
+		body.EXPECT().Read(gomock.Any()).DoAndReturn(reader).Times(1)
+
+		response := &http.Response{Body: body}
+
+		requester := mocks.NewRestRequester(ctrl)
+		requester.EXPECT().Do(gomock.Any()).Return(response, nil).Times(1)
+
+		requestGenerator := mocks.NewRestRequesterGenerator(ctrl)
+		requestGenerator.EXPECT().Create().Return(requester, nil).Times(1)
+		require.NoError(t, container.Decorate(func(generator config.RestRequesterGenerator) config.RestRequesterGenerator {
+			return requestGenerator
+		}))
+
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			got, e := facade.GetSource("my_source")
+			assert.NotNil(t, got)
+			assert.NoError(t, e)
+
+			assert.Equal(t, "value", got.Get("field"))
+		}))
+	})
 }
 
 func Test_observableRestSource_Reload(t *testing.T) {
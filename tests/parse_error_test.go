@@ -0,0 +1,190 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/dig"
+
+	flam "github.com/happyhippyhippo/flam"
+	config "github.com/happyhippyhippo/flam-config"
+	filesystem "github.com/happyhippyhippo/flam-filesystem"
+	flamTime "github.com/happyhippyhippo/flam-time"
+)
+
+func Test_ParseError(t *testing.T) {
+	t.Run("should format the error message", func(t *testing.T) {
+		cause := errors.New("cause")
+		scenarios := []struct {
+			test     string
+			err      *config.ParseError
+			expected string
+		}{
+			{
+				test:     "without position",
+				err:      &config.ParseError{Source: "my_source", Path: "/file.json", Err: cause},
+				expected: "config parse error: my_source => /file.json => cause",
+			},
+			{
+				test:     "with line",
+				err:      &config.ParseError{Source: "my_source", Path: "/file.ini", Line: 2, Err: cause},
+				expected: "config parse error: my_source => /file.ini:2 => cause",
+			},
+			{
+				test:     "with line, column and snippet",
+				err:      &config.ParseError{Source: "my_source", Path: "/file.yaml", Line: 2, Column: 3, Snippet: "a: [", Err: cause},
+				expected: "config parse error: my_source => /file.yaml:2:3 => cause => \"a: [\"",
+			},
+		}
+
+		for _, scenario := range scenarios {
+			t.Run(scenario.test, func(t *testing.T) {
+				assert.Equal(t, scenario.expected, scenario.err.Error())
+				assert.ErrorIs(t, scenario.err, config.ErrParse)
+				assert.ErrorIs(t, scenario.err, cause)
+			})
+		}
+	})
+
+	t.Run("should locate the failure in the parsed file", func(t *testing.T) {
+		scenarios := []struct {
+			test    string
+			driver  string
+			path    string
+			content string
+			line    int
+			column  int
+			snippet string
+		}{
+			{
+				test:    "json syntax",
+				driver:  config.ParserDriverJson,
+				path:    "/config/file.json",
+				content: "{\n  \"field\": \"value\",\n  \"other\" 1\n}",
+				line:    3,
+				column:  11,
+				snippet: "  \"other\" 1",
+			},
+			{
+				test:    "json5 syntax after multi-line comment and re-encoded string",
+				driver:  config.ParserDriverJson5,
+				path:    "/config/file.json5",
+				content: "{\n  /* multi\n     line */\n  field: 'a \\u00e9 \\x41',\n  other: 1 2\n}",
+				line:    5,
+				column:  12,
+				snippet: "  other: 1 2",
+			},
+			{
+				test:    "json type",
+				driver:  config.ParserDriverJson,
+				path:    "/config/file.json",
+				content: "[1, 2]",
+				line:    1,
+				column:  1,
+				snippet: "[1, 2]",
+			},
+			{
+				test:    "yaml",
+				driver:  config.ParserDriverYaml,
+				path:    "/config/file.yaml",
+				content: "field: value\nother: value: invalid\n",
+				line:    2,
+				snippet: "other: value: invalid",
+			},
+			{
+				test:    "toml",
+				driver:  config.ParserDriverToml,
+				path:    "/config/file.toml",
+				content: "field = \"value\"\nother = \n",
+				line:    2,
+				column:  9,
+				snippet: "other = ",
+			},
+			{
+				test:    "ini",
+				driver:  config.ParserDriverIni,
+				path:    "/config/file.ini",
+				content: "[section]\nfield = value\ninvalid line\n",
+				line:    3,
+				snippet: "invalid line",
+			},
+			{
+				test:    "properties",
+				driver:  config.ParserDriverProperties,
+				path:    "/config/file.properties",
+				content: "field = value\n\\u12\n",
+				line:    2,
+				snippet: "\\u12",
+			},
+			{
+				test:    "hocon",
+				driver:  config.ParserDriverHocon,
+				path:    "/config/file.conf",
+				content: "field = value\nother = [1, 2\n",
+				line:    3,
+				column:  1,
+			},
+			{
+				test:    "xml",
+				driver:  config.ParserDriverXml,
+				path:    "/config/file.xml",
+				content: "<root>\n  <field>value</other>\n</root>",
+				line:    2,
+				snippet: "  <field>value</other>",
+			},
+			{
+				test:    "hcl",
+				driver:  config.ParserDriverHcl,
+				path:    "/config/file.hcl",
+				content: "field = \"value\"\nother = \n",
+				line:    2,
+				column:  9,
+				snippet: "other = ",
+			},
+		}
+
+		for _, scenario := range scenarios {
+			t.Run(scenario.test, func(t *testing.T) {
+				config.Defaults = flam.Bag{}
+				_ = config.Defaults.Set(config.PathBoot, true)
+				_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+					"my_parser": flam.Bag{
+						"driver": scenario.driver,
+					}})
+				_ = config.Defaults.Set(config.PathSources, flam.Bag{
+					"my_source": flam.Bag{
+						"driver": config.SourceDriverDir,
+						"disk":   "my_disk",
+						"parser": "my_parser",
+						"path":   "/config",
+					}})
+				defer func() { config.Defaults = flam.Bag{} }()
+
+				disk := afero.NewMemMapFs()
+				require.NoError(t, afero.WriteFile(disk, scenario.path, []byte(scenario.content), 0o644))
+
+				container := dig.New()
+				require.NoError(t, flamTime.NewProvider().Register(container))
+				require.NoError(t, filesystem.NewProvider().Register(container))
+				require.NoError(t, config.NewProvider().Register(container))
+				require.NoError(t, container.Invoke(func(facade filesystem.Facade) error {
+					return facade.AddDisk("my_disk", disk)
+				}))
+
+				e := config.NewProvider().(flam.BootableProvider).Boot(container)
+				assert.ErrorIs(t, e, config.ErrParse)
+
+				var parseErr *config.ParseError
+				require.ErrorAs(t, e, &parseErr)
+				assert.Equal(t, "my_source", parseErr.Source)
+				assert.Equal(t, scenario.path, parseErr.Path)
+				assert.Equal(t, scenario.line, parseErr.Line)
+				assert.Equal(t, scenario.column, parseErr.Column)
+				assert.Equal(t, scenario.snippet, parseErr.Snippet)
+			})
+		}
+	})
+}
@@ -0,0 +1,320 @@
+package tests
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/dig"
+
+	flam "github.com/happyhippyhippo/flam"
+	config "github.com/happyhippyhippo/flam-config"
+	mocks "github.com/happyhippyhippo/flam-config/tests/mocks"
+	filesystem "github.com/happyhippyhippo/flam-filesystem"
+	flamTime "github.com/happyhippyhippo/flam-time"
+)
+
+func Test_parserRegistry(t *testing.T) {
+	parsers := flam.Bag{
+		"yaml": flam.Bag{"driver": config.ParserDriverYaml},
+		"json": flam.Bag{"driver": config.ParserDriverJson},
+		"toml": flam.Bag{"driver": config.ParserDriverToml},
+	}
+
+	t.Run("should select the parser of each dir file by extension", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathBoot, true)
+		_ = config.Defaults.Set(config.PathParsers, parsers)
+		_ = config.Defaults.Set(config.PathSources, flam.Bag{
+			"my_source": flam.Bag{
+				"driver": config.SourceDriverDir,
+				"disk":   "my_disk",
+				"path":   "/config",
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		disk := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(disk, "/config/a.yaml", []byte("yaml: value"), 0o644))
+		require.NoError(t, afero.WriteFile(disk, "/config/b.JSON", []byte(`{"json": "value"}`), 0o644))
+		require.NoError(t, afero.WriteFile(disk, "/config/c.toml", []byte(`toml = "value"`), 0o644))
+		require.NoError(t, afero.WriteFile(disk, "/config/README.md", []byte("# readme"), 0o644))
+		require.NoError(t, afero.WriteFile(disk, "/config/nginx.conf", []byte("server { listen 80; }"), 0o644))
+		require.NoError(t, afero.WriteFile(disk, "/config/pom.xml", []byte("<project>"), 0o644))
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, container.Invoke(func(facade filesystem.Facade) error {
+			return facade.AddDisk("my_disk", disk)
+		}))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			assert.Equal(t, "value", facade.Get("yaml"))
+			assert.Equal(t, "value", facade.Get("json"))
+			assert.Equal(t, "value", facade.Get("toml"))
+		}))
+	})
+
+	t.Run("should fall back to the default file parser only for unmapped extensions", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathBoot, true)
+		_ = config.Defaults.Set(config.PathDefaultFileParser, "yaml")
+		_ = config.Defaults.Set(config.PathParsers, parsers)
+		_ = config.Defaults.Set(config.PathSources, flam.Bag{
+			"my_source": flam.Bag{
+				"driver": config.SourceDriverDir,
+				"disk":   "my_disk",
+				"path":   "/config",
+			}})
+		defer func() {
+			config.Defaults = flam.Bag{}
+			config.DefaultFileParser = ""
+		}()
+
+		disk := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(disk, "/config/a.yaml", []byte("yaml: value"), 0o644))
+		require.NoError(t, afero.WriteFile(disk, "/config/b.toml", []byte(`toml = "value"`), 0o644))
+		require.NoError(t, afero.WriteFile(disk, "/config/c.cfg", []byte("cfg: value"), 0o644))
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, container.Invoke(func(facade filesystem.Facade) error {
+			return facade.AddDisk("my_disk", disk)
+		}))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			assert.Equal(t, "value", facade.Get("yaml"))
+			assert.Equal(t, "value", facade.Get("toml"))
+			assert.Equal(t, "value", facade.Get("cfg"))
+		}))
+	})
+
+	t.Run("should use the configured extension mapping", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathBoot, true)
+		_ = config.Defaults.Set(config.PathParsers, parsers)
+		_ = config.Defaults.Set(config.PathParserExtensions, flam.Bag{".cfg": "json", "yaml": "json"})
+		_ = config.Defaults.Set(config.PathSources, flam.Bag{
+			"my_source": flam.Bag{
+				"driver": config.SourceDriverDir,
+				"disk":   "my_disk",
+				"path":   "/config",
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		disk := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(disk, "/config/a.cfg", []byte(`{"cfg": "value"}`), 0o644))
+		require.NoError(t, afero.WriteFile(disk, "/config/b.yaml", []byte(`{"yaml": "value"}`), 0o644))
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, container.Invoke(func(facade filesystem.Facade) error {
+			return facade.AddDisk("my_disk", disk)
+		}))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			assert.Equal(t, "value", facade.Get("cfg"))
+			assert.Equal(t, "value", facade.Get("yaml"))
+		}))
+	})
+
+	t.Run("should let an explicit parser override the extension", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathBoot, true)
+		_ = config.Defaults.Set(config.PathParsers, parsers)
+		_ = config.Defaults.Set(config.PathSources, flam.Bag{
+			"my_source": flam.Bag{
+				"driver": config.SourceDriverFile,
+				"disk":   "my_disk",
+				"parser": "yaml",
+				"path":   "/config/file.json",
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		disk := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(disk, "/config/file.json", []byte("field: value"), 0o644))
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, container.Invoke(func(facade filesystem.Facade) error {
+			return facade.AddDisk("my_disk", disk)
+		}))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			assert.Equal(t, "value", facade.Get("field"))
+		}))
+	})
+
+	t.Run("should select the file parser by extension", func(t *testing.T) {
+		scenarios := []struct {
+			test string
+			path string
+			data string
+			err  error
+		}{
+			{
+				test: "registered extension",
+				path: "/config/file.yml",
+				data: "field: value",
+			},
+			{
+				test: "unregistered extension",
+				path: "/config/file.txt",
+				data: "field: value",
+				err:  config.ErrParserNotFoundForExtension,
+			},
+			{
+				test: "no extension",
+				path: "/config/file",
+				data: "field: value",
+				err:  config.ErrParserNotFoundForExtension,
+			},
+			{
+				test: "registered extension without configured parser",
+				path: "/config/file.ini",
+				data: "field = value",
+				err:  config.ErrParserNotFoundForExtension,
+			},
+		}
+
+		for _, scenario := range scenarios {
+			t.Run(scenario.test, func(t *testing.T) {
+				config.Defaults = flam.Bag{}
+				_ = config.Defaults.Set(config.PathBoot, true)
+				_ = config.Defaults.Set(config.PathParsers, parsers)
+				_ = config.Defaults.Set(config.PathSources, flam.Bag{
+					"my_source": flam.Bag{
+						"driver": config.SourceDriverFile,
+						"disk":   "my_disk",
+						"path":   scenario.path,
+					}})
+				defer func() { config.Defaults = flam.Bag{} }()
+
+				disk := afero.NewMemMapFs()
+				require.NoError(t, afero.WriteFile(disk, scenario.path, []byte(scenario.data), 0o644))
+
+				container := dig.New()
+				require.NoError(t, flamTime.NewProvider().Register(container))
+				require.NoError(t, filesystem.NewProvider().Register(container))
+				require.NoError(t, config.NewProvider().Register(container))
+				require.NoError(t, container.Invoke(func(facade filesystem.Facade) error {
+					return facade.AddDisk("my_disk", disk)
+				}))
+
+				e := config.NewProvider().(flam.BootableProvider).Boot(container)
+				if scenario.err != nil {
+					assert.ErrorIs(t, e, scenario.err)
+					return
+				}
+
+				require.NoError(t, e)
+				assert.NoError(t, container.Invoke(func(facade config.Facade) {
+					assert.Equal(t, "value", facade.Get("field"))
+				}))
+			})
+		}
+	})
+
+	t.Run("should select the rest parser by content type", func(t *testing.T) {
+		scenarios := []struct {
+			test        string
+			contentType string
+			data        string
+			err         error
+		}{
+			{
+				test:        "registered content type",
+				contentType: "application/json; charset=utf-8",
+				data:        `{"config": {"field": "value"}}`,
+			},
+			{
+				test:        "structured syntax suffix",
+				contentType: "application/vnd.config+yaml",
+				data:        "config:\n  field: value",
+			},
+			{
+				test:        "unregistered content type",
+				contentType: "text/plain",
+				data:        "field: value",
+				err:         config.ErrParserNotFoundForContentType,
+			},
+			{
+				test:        "missing content type",
+				contentType: "",
+				data:        "field: value",
+				err:         config.ErrParserNotFoundForContentType,
+			},
+		}
+
+		for _, scenario := range scenarios {
+			t.Run(scenario.test, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				config.Defaults = flam.Bag{}
+				_ = config.Defaults.Set(config.PathBoot, true)
+				_ = config.Defaults.Set(config.PathParsers, parsers)
+				_ = config.Defaults.Set(config.PathSources, flam.Bag{
+					"my_source": flam.Bag{
+						"driver": config.SourceDriverRest,
+						"uri":    "http://uri",
+						"path":   flam.Bag{"config": "config"},
+					}})
+				defer func() { config.Defaults = flam.Bag{} }()
+
+				container := dig.New()
+				require.NoError(t, flamTime.NewProvider().Register(container))
+				require.NoError(t, filesystem.NewProvider().Register(container))
+				require.NoError(t, config.NewProvider().Register(container))
+
+				reader := func(b []byte) (int, error) {
+					copy(b, scenario.data)
+					return len(scenario.data), io.EOF
+				}
+
+				body := mocks.NewReadCloser(ctrl)
+				body.EXPECT().Read(gomock.Any()).DoAndReturn(reader).MaxTimes(1)
+
+				response := &http.Response{Header: http.Header{}, Body: body}
+				if scenario.contentType != "" {
+					response.Header.Set("Content-Type", scenario.contentType)
+				}
+
+				requester := mocks.NewRestRequester(ctrl)
+				requester.EXPECT().Do(gomock.Any()).Return(response, nil).Times(1)
+
+				requestGenerator := mocks.NewRestRequesterGenerator(ctrl)
+				requestGenerator.EXPECT().Create().Return(requester, nil).Times(1)
+				require.NoError(t, container.Decorate(func(generator config.RestRequesterGenerator) config.RestRequesterGenerator {
+					return requestGenerator
+				}))
+
+				e := config.NewProvider().(flam.BootableProvider).Boot(container)
+				if scenario.err != nil {
+					assert.ErrorIs(t, e, scenario.err)
+					return
+				}
+
+				require.NoError(t, e)
+				assert.NoError(t, container.Invoke(func(facade config.Facade) {
+					assert.Equal(t, "value", facade.Get("field"))
+				}))
+			})
+		}
+	})
+}
@@ -296,10 +296,16 @@ func Test_restSource(t *testing.T) {
 			return requestGenerator
 		}))
 
-		assert.ErrorContains(
-			t,
-			config.NewProvider().(flam.BootableProvider).Boot(container),
-			"unexpected end of JSON input")
+		e := config.NewProvider().(flam.BootableProvider).Boot(container)
+		assert.ErrorContains(t, e, "unexpected end of JSON input")
+
+		var parseErr *config.ParseError
+		require.ErrorAs(t, e, &parseErr)
+		assert.Equal(t, "my_source", parseErr.Source)
+		assert.Equal(t, "http://uri", parseErr.Path)
+		assert.Equal(t, 1, parseErr.Line)
+		assert.Equal(t, 1, parseErr.Column)
+		assert.Equal(t, "{", parseErr.Snippet)
 	})
 
 	t.Run("should return config not found in response error", func(t *testing.T) {
@@ -459,4 +465,60 @@ func Test_restSource(t *testing.T) {
 			assert.Equal(t, "value", got.Get("field"))
 		}))
 	})
+
+	t.Run("should correctly load the config from a xml response", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathBoot, true)
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"xml": flam.Bag{
+				"driver": config.ParserDriverXml,
+			}})
+		_ = config.Defaults.Set(config.PathSources, flam.Bag{
+			"my_source": flam.Bag{
+				"driver":   config.SourceDriverRest,
+				"parser":   "xml",
+				"uri":      "http://uri",
+				"path":     flam.Bag{"config": "response.config"},
+				"priority": 123,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		data := "<response><config><field>value</field></config></response>"
+		reader := func(b []byte) (int, error) {
+			copy(b, data)
+			return len(data), io.EOF
+		}
+
+		container := dig.New()
+		require.NoError(t, time.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+
+		body := mocks.NewReadCloser(ctrl)
+		body.EXPECT().Read(gomock.Any()).DoAndReturn(reader).Times(1)
+
+		response := &http.Response{Body: body}
+
+		requester := mocks.NewRestRequester(ctrl)
+		requester.EXPECT().Do(gomock.Any()).Return(response, nil).Times(1)
+
+		requestGenerator := mocks.NewRestRequesterGenerator(ctrl)
+		requestGenerator.EXPECT().Create().Return(requester, nil).Times(1)
+		require.NoError(t, container.Decorate(func(generator config.RestRequesterGenerator) config.RestRequesterGenerator {
+			return requestGenerator
+		}))
+
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			got, e := facade.GetSource("my_source")
+			require.NotNil(t, got)
+			require.NoError(t, e)
+
+			assert.Equal(t, "value", got.Get("field"))
+		}))
+	})
 }
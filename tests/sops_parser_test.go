@@ -0,0 +1,475 @@
+package tests
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/golang/mock/gomock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/dig"
+
+	flam "github.com/happyhippyhippo/flam"
+	config "github.com/happyhippyhippo/flam-config"
+	mocks "github.com/happyhippyhippo/flam-config/tests/mocks"
+	filesystem "github.com/happyhippyhippo/flam-filesystem"
+	flamTime "github.com/happyhippyhippo/flam-time"
+)
+
+const sopsLastModified = "2024-01-01T00:00:00Z"
+
+type sopsDocument struct {
+	key []byte
+	mac hash.Hash
+}
+
+func newSopsDocument(t *testing.T) *sopsDocument {
+	key := make([]byte, 32)
+	_, e := rand.Read(key)
+	require.NoError(t, e)
+
+	return &sopsDocument{key: key, mac: sha512.New()}
+}
+
+func (document *sopsDocument) encrypt(t *testing.T, plain, valueType, additionalData string) string {
+	block, e := aes.NewCipher(document.key)
+	require.NoError(t, e)
+	gcm, e := cipher.NewGCMWithNonceSize(block, 32)
+	require.NoError(t, e)
+
+	iv := make([]byte, 32)
+	_, e = rand.Read(iv)
+	require.NoError(t, e)
+
+	sealed := gcm.Seal(nil, iv, []byte(plain), []byte(additionalData))
+	data, tag := sealed[:len(sealed)-gcm.Overhead()], sealed[len(sealed)-gcm.Overhead():]
+
+	return fmt.Sprintf(
+		"ENC[AES256_GCM,data:%s,iv:%s,tag:%s,type:%s]",
+		base64.StdEncoding.EncodeToString(data),
+		base64.StdEncoding.EncodeToString(iv),
+		base64.StdEncoding.EncodeToString(tag),
+		valueType)
+}
+
+func (document *sopsDocument) value(t *testing.T, plain, valueType, path string) string {
+	document.mac.Write([]byte(plain))
+
+	return document.encrypt(t, plain, valueType, path)
+}
+
+func (document *sopsDocument) unencrypted(plain string) {
+	document.mac.Write([]byte(plain))
+}
+
+func (document *sopsDocument) metadata(t *testing.T, recipient age.Recipient) (string, string) {
+	mac := document.encrypt(t, fmt.Sprintf("%X", document.mac.Sum(nil)), "str", sopsLastModified)
+
+	buffer := &bytes.Buffer{}
+	armored := armor.NewWriter(buffer)
+	writer, e := age.Encrypt(armored, recipient)
+	require.NoError(t, e)
+	_, e = writer.Write(document.key)
+	require.NoError(t, e)
+	require.NoError(t, writer.Close())
+	require.NoError(t, armored.Close())
+
+	return buffer.String(), mac
+}
+
+func (document *sopsDocument) yaml(t *testing.T, recipient age.Recipient) string {
+	user := document.value(t, "admin", "str", "db:user:")
+	port := document.value(t, "5432", "int", "db:port:")
+	hostA := document.value(t, "a", "str", "db:hosts:")
+	hostB := document.value(t, "b", "str", "db:hosts:")
+	document.unencrypted("True")
+	ratio := document.value(t, "1.5", "float", "ratio:")
+	enabled := document.value(t, "True", "bool", "enabled:")
+	enc, mac := document.metadata(t, recipient)
+
+	return fmt.Sprintf(`db:
+    user: %s
+    port: %s
+    hosts:
+        - %s
+        - %s
+    debug_unencrypted: true
+ratio: %s
+enabled: %s
+sops:
+    age:
+        - recipient: %s
+          enc: |
+%s
+    lastmodified: "%s"
+    mac: %s
+    unencrypted_suffix: _unencrypted
+    version: 3.9.0
+`, user, port, hostA, hostB, ratio, enabled, recipient, indent(enc, "            "), sopsLastModified, mac)
+}
+
+func indent(text, prefix string) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = prefix + line
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func Test_sopsParser(t *testing.T) {
+	identity, e := age.GenerateX25519Identity()
+	require.NoError(t, e)
+
+	expected := flam.Bag{
+		"db": flam.Bag{
+			"user":              "admin",
+			"port":              5432,
+			"hosts":             []any{"a", "b"},
+			"debug_unencrypted": true,
+		},
+		"ratio":   1.5,
+		"enabled": true,
+	}
+
+	t.Run("should return reader error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverSops,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		expectedErr := errors.New("reader error")
+		reader := mocks.NewReadCloser(ctrl)
+		reader.EXPECT().Read(gomock.Any()).Return(0, expectedErr).Times(1)
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(reader)
+			assert.Nil(t, got)
+			assert.ErrorIs(t, e, expectedErr)
+		}))
+	})
+
+	t.Run("should return invalid key error", func(t *testing.T) {
+		t.Setenv(config.DefaultSopsAgeKeyEnv, "invalid key")
+
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverSops,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			got, e := facade.GetParser("my_parser")
+			assert.Nil(t, got)
+			assert.Error(t, e)
+		}))
+	})
+
+	t.Run("should return missing key file error", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver":   config.ParserDriverSops,
+				"disk":     "my_disk",
+				"key_file": "/keys.txt",
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, container.Invoke(func(facade filesystem.Facade) error {
+			return facade.AddDisk("my_disk", afero.NewMemMapFs())
+		}))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			got, e := facade.GetParser("my_parser")
+			assert.Nil(t, got)
+			assert.Error(t, e)
+		}))
+	})
+
+	t.Run("should parse documents", func(t *testing.T) {
+		other, e := age.GenerateX25519Identity()
+		require.NoError(t, e)
+
+		scenarios := []struct {
+			test     string
+			env      map[string]string
+			config   flam.Bag
+			data     func(t *testing.T) string
+			expected flam.Bag
+			err      error
+		}{
+			{
+				test: "document without sops metadata",
+				data: func(*testing.T) string {
+					return "db:\n  user: admin\n"
+				},
+				expected: flam.Bag{"db": flam.Bag{"user": "admin"}},
+			},
+			{
+				test: "identity from the default env var",
+				env:  map[string]string{config.DefaultSopsAgeKeyEnv: "# comment\n" + identity.String()},
+				data: func(t *testing.T) string {
+					return newSopsDocument(t).yaml(t, identity.Recipient())
+				},
+				expected: expected,
+			},
+			{
+				test:   "identity from a custom env var",
+				env:    map[string]string{"MY_AGE_KEY": identity.String()},
+				config: flam.Bag{"key_env": "MY_AGE_KEY"},
+				data: func(t *testing.T) string {
+					return newSopsDocument(t).yaml(t, identity.Recipient())
+				},
+				expected: expected,
+			},
+			{
+				test:   "identity from a key file",
+				config: flam.Bag{"disk": "my_disk", "key_file": "/keys.txt"},
+				data: func(t *testing.T) string {
+					return newSopsDocument(t).yaml(t, identity.Recipient())
+				},
+				expected: expected,
+			},
+			{
+				test: "json document",
+				env:  map[string]string{config.DefaultSopsAgeKeyEnv: identity.String()},
+				data: func(t *testing.T) string {
+					document := newSopsDocument(t)
+					user := document.value(t, "admin", "str", "db:user:")
+					enc, mac := document.metadata(t, identity.Recipient())
+					return fmt.Sprintf(
+						`{"db": {"user": %q}, "sops": {"age": [{"recipient": %q, "enc": %q}], "lastmodified": %q, "mac": %q}}`,
+						user, identity.Recipient().String(), enc, sopsLastModified, mac)
+				},
+				expected: flam.Bag{"db": flam.Bag{"user": "admin"}},
+			},
+			{
+				test: "no matching identity",
+				env:  map[string]string{config.DefaultSopsAgeKeyEnv: other.String()},
+				data: func(t *testing.T) string {
+					return newSopsDocument(t).yaml(t, identity.Recipient())
+				},
+				err: config.ErrSopsDataKeyNotFound,
+			},
+			{
+				test: "tampered unencrypted value",
+				env:  map[string]string{config.DefaultSopsAgeKeyEnv: identity.String()},
+				data: func(t *testing.T) string {
+					data := newSopsDocument(t).yaml(t, identity.Recipient())
+					return strings.Replace(data, "debug_unencrypted: true", "debug_unencrypted: false", 1)
+				},
+				err: config.ErrSopsMacMismatch,
+			},
+			{
+				test: "moved encrypted value",
+				env:  map[string]string{config.DefaultSopsAgeKeyEnv: identity.String()},
+				data: func(t *testing.T) string {
+					data := newSopsDocument(t).yaml(t, identity.Recipient())
+					return strings.Replace(data, "    user: ", "    name: ", 1)
+				},
+				err: config.ErrSopsDecryption,
+			},
+			{
+				test: "non mapping document",
+				data: func(*testing.T) string {
+					return "- value\n"
+				},
+				err: config.ErrSopsInvalidDocument,
+			},
+		}
+
+		for _, scenario := range scenarios {
+			t.Run(scenario.test, func(t *testing.T) {
+				t.Setenv(config.DefaultSopsAgeKeyEnv, "")
+				for key, value := range scenario.env {
+					t.Setenv(key, value)
+				}
+
+				parserConfig := flam.Bag{"driver": config.ParserDriverSops}
+				parserConfig.Merge(scenario.config)
+
+				config.Defaults = flam.Bag{}
+				_ = config.Defaults.Set(config.PathParsers, flam.Bag{"my_parser": parserConfig})
+				defer func() { config.Defaults = flam.Bag{} }()
+
+				disk := afero.NewMemMapFs()
+				require.NoError(t, afero.WriteFile(disk, "/keys.txt", []byte("# created: now\n"+identity.String()+"\n"), 0o600))
+
+				container := dig.New()
+				require.NoError(t, flamTime.NewProvider().Register(container))
+				require.NoError(t, filesystem.NewProvider().Register(container))
+				require.NoError(t, config.NewProvider().Register(container))
+				require.NoError(t, container.Invoke(func(facade filesystem.Facade) error {
+					return facade.AddDisk("my_disk", disk)
+				}))
+				require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+				assert.NoError(t, container.Invoke(func(facade config.Facade) {
+					parser, e := facade.GetParser("my_parser")
+					require.NoError(t, e)
+
+					got, e := parser.Parse(io.NopCloser(strings.NewReader(scenario.data(t))))
+					if scenario.err != nil {
+						assert.Nil(t, got)
+						assert.ErrorIs(t, e, scenario.err)
+						return
+					}
+
+					require.NoError(t, e)
+					assert.Equal(t, scenario.expected, got)
+				}))
+			})
+		}
+	})
+
+	t.Run("should load encrypted file sources", func(t *testing.T) {
+		t.Setenv(config.DefaultSopsAgeKeyEnv, identity.String())
+
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathBoot, true)
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"sops": flam.Bag{
+				"driver": config.ParserDriverSops,
+			}})
+		_ = config.Defaults.Set(config.PathSources, flam.Bag{
+			"my_source": flam.Bag{
+				"driver": config.SourceDriverFile,
+				"disk":   "my_disk",
+				"parser": "sops",
+				"path":   "/config/secrets.enc.yaml",
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		disk := afero.NewMemMapFs()
+		data := newSopsDocument(t).yaml(t, identity.Recipient())
+		require.NoError(t, afero.WriteFile(disk, "/config/secrets.enc.yaml", []byte(data), 0o644))
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, container.Invoke(func(facade filesystem.Facade) error {
+			return facade.AddDisk("my_disk", disk)
+		}))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			assert.Equal(t, "admin", facade.Get("db.user"))
+			assert.Equal(t, 5432, facade.Get("db.port"))
+			assert.Nil(t, facade.Get("sops"))
+		}))
+	})
+
+	t.Run("should verify documents encrypted by the sops cli", func(t *testing.T) {
+		fixture, e := os.ReadFile("./testdata/sops/secrets.enc.yaml")
+		require.NoError(t, e)
+
+		scenarios := []struct {
+			test     string
+			data     string
+			expected flam.Bag
+			err      error
+		}{
+			{
+				test:     "untouched document",
+				data:     string(fixture),
+				expected: expected,
+			},
+			{
+				test: "tampered unencrypted value",
+				data: strings.Replace(string(fixture), "debug_unencrypted: true", "debug_unencrypted: false", 1),
+				err:  config.ErrSopsMacMismatch,
+			},
+			{
+				test: "removed encrypted value",
+				data: regexp.MustCompile(`(?m)^enabled: .*\n`).ReplaceAllString(string(fixture), ""),
+				err:  config.ErrSopsMacMismatch,
+			},
+			{
+				test: "tampered mac timestamp",
+				data: regexp.MustCompile(`lastmodified: "[^"]*"`).ReplaceAllString(string(fixture), `lastmodified: "2000-01-01T00:00:00Z"`),
+				err:  config.ErrSopsDecryption,
+			},
+		}
+
+		for _, scenario := range scenarios {
+			t.Run(scenario.test, func(t *testing.T) {
+				t.Setenv(config.DefaultSopsAgeKeyEnv, "")
+
+				config.Defaults = flam.Bag{}
+				_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+					"my_parser": flam.Bag{
+						"driver":   config.ParserDriverSops,
+						"disk":     "my_disk",
+						"key_file": "/keys.txt",
+					}})
+				defer func() { config.Defaults = flam.Bag{} }()
+
+				container := dig.New()
+				require.NoError(t, flamTime.NewProvider().Register(container))
+				require.NoError(t, filesystem.NewProvider().Register(container))
+				require.NoError(t, config.NewProvider().Register(container))
+				require.NoError(t, container.Invoke(func(facade filesystem.Facade) error {
+					return facade.AddDisk("my_disk", afero.NewReadOnlyFs(afero.NewBasePathFs(afero.NewOsFs(), "./testdata/sops")))
+				}))
+				require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+				assert.NoError(t, container.Invoke(func(facade config.Facade) {
+					parser, e := facade.GetParser("my_parser")
+					require.NoError(t, e)
+
+					got, e := parser.Parse(io.NopCloser(strings.NewReader(scenario.data)))
+					if scenario.err != nil {
+						assert.Nil(t, got)
+						assert.ErrorIs(t, e, scenario.err)
+						return
+					}
+
+					require.NoError(t, e)
+					assert.Equal(t, scenario.expected, got)
+				}))
+			})
+		}
+	})
+}
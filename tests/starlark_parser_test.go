@@ -0,0 +1,301 @@
+package tests
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/dig"
+
+	flam "github.com/happyhippyhippo/flam"
+	config "github.com/happyhippyhippo/flam-config"
+	mocks "github.com/happyhippyhippo/flam-config/tests/mocks"
+	filesystem "github.com/happyhippyhippo/flam-filesystem"
+	flamTime "github.com/happyhippyhippo/flam-time"
+)
+
+func Test_starlarkParser(t *testing.T) {
+	t.Run("should return reader error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverStarlark,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		expectedErr := errors.New("reader error")
+		reader := mocks.NewReadCloser(ctrl)
+		reader.EXPECT().Read(gomock.Any()).Return(0, expectedErr).Times(1)
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(reader)
+			assert.Nil(t, got)
+			assert.ErrorIs(t, e, expectedErr)
+		}))
+	})
+
+	t.Run("should return execution errors", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver":    config.ParserDriverStarlark,
+				"max_steps": 1000,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		scenarios := []struct {
+			test     string
+			data     string
+			err      error
+			expected string
+		}{
+			{
+				test:     "syntax",
+				data:     "field = ",
+				err:      config.ErrStarlarkExecution,
+				expected: "got end of file",
+			},
+			{
+				test:     "runtime",
+				data:     "field = 1 + 'a'",
+				err:      config.ErrStarlarkExecution,
+				expected: "unknown binary op",
+			},
+			{
+				test:     "step limit",
+				data:     "n = 0\nwhile True:\n  n += 1",
+				err:      config.ErrStarlarkExecution,
+				expected: "too many steps",
+			},
+			{
+				test:     "load without disk",
+				data:     "load('lib.star', 'value')",
+				err:      config.ErrStarlarkExecution,
+				expected: config.ErrStarlarkDiskNotFound.Error(),
+			},
+			{
+				test:     "unsupported value",
+				data:     "field = struct",
+				err:      config.ErrStarlarkExecution,
+				expected: "undefined: struct",
+			},
+			{
+				test:     "non string key",
+				data:     "field = {1: 'a'}",
+				err:      config.ErrStarlarkInvalidValue,
+				expected: "field => non-string key int",
+			},
+			{
+				test:     "big integer",
+				data:     "field = [1 << 70]",
+				err:      config.ErrStarlarkInvalidValue,
+				expected: "field[0] => int",
+			},
+			{
+				test:     "set value",
+				data:     "field = set([1])",
+				err:      config.ErrStarlarkInvalidValue,
+				expected: "field => set",
+			},
+		}
+
+		for _, scenario := range scenarios {
+			t.Run(scenario.test, func(t *testing.T) {
+				assert.NoError(t, container.Invoke(func(facade config.Facade) {
+					parser, e := facade.GetParser("my_parser")
+					require.NoError(t, e)
+
+					got, e := parser.Parse(strings.NewReader(scenario.data))
+					assert.Nil(t, got)
+					assert.ErrorIs(t, e, scenario.err)
+					assert.ErrorContains(t, e, scenario.expected)
+				}))
+			})
+		}
+	})
+
+	t.Run("should return the exported globals", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverStarlark,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		data := `
+_base = 8000
+
+def port(i):
+    return _base + i
+
+Workers = [{"name": "worker-%d" % i, "port": port(i)} for i in range(2)]
+debug = True
+ratio = 0.5
+empty = None
+pair = ("a", "b")
+print("ignored")
+`
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(strings.NewReader(data))
+			require.NoError(t, e)
+
+			assert.Equal(t, flam.Bag{
+				"workers": []any{
+					flam.Bag{"name": "worker-0", "port": 8000},
+					flam.Bag{"name": "worker-1", "port": 8001},
+				},
+				"debug": true,
+				"ratio": 0.5,
+				"empty": nil,
+				"pair":  []any{"a", "b"},
+			}, got)
+		}))
+	})
+
+	t.Run("should return the config dict when defined", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverStarlark,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		data := `
+other = "ignored"
+config = {"db": {"host": "localhost", "port": 5432}}
+`
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(strings.NewReader(data))
+			require.NoError(t, e)
+
+			assert.Equal(t, flam.Bag{"db": flam.Bag{"host": "localhost", "port": 5432}}, got)
+		}))
+	})
+
+	t.Run("should load modules through the source disk", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathBoot, true)
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverStarlark,
+			}})
+		_ = config.Defaults.Set(config.PathSources, flam.Bag{
+			"my_source": flam.Bag{
+				"driver": config.SourceDriverFile,
+				"disk":   "my_disk",
+				"parser": "my_parser",
+				"path":   "/config/main.star",
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		disk := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(disk, "/config/main.star", []byte(`
+load("lib/helpers.star", "host")
+load("/shared/values.star", "port")
+
+db = {"host": host("db"), "port": port}
+`), 0o644))
+		require.NoError(t, afero.WriteFile(disk, "/config/lib/helpers.star", []byte(`
+load("../../shared/values.star", "domain")
+
+def host(name):
+    return name + "." + domain
+`), 0o644))
+		require.NoError(t, afero.WriteFile(disk, "/shared/values.star", []byte(`
+domain = "local"
+port = 5432
+`), 0o644))
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, container.Invoke(func(facade filesystem.Facade) error {
+			return facade.AddDisk("my_disk", disk)
+		}))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			assert.Equal(t, flam.Bag{"host": "db.local", "port": 5432}, facade.Get("db"))
+		}))
+	})
+
+	t.Run("should return load cycle error", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathBoot, true)
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverStarlark,
+			}})
+		_ = config.Defaults.Set(config.PathSources, flam.Bag{
+			"my_source": flam.Bag{
+				"driver": config.SourceDriverFile,
+				"disk":   "my_disk",
+				"parser": "my_parser",
+				"path":   "/config/main.star",
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		disk := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(disk, "/config/main.star", []byte(`load("a.star", "a")`), 0o644))
+		require.NoError(t, afero.WriteFile(disk, "/config/a.star", []byte(`load("b.star", "b")
+a = 1`), 0o644))
+		require.NoError(t, afero.WriteFile(disk, "/config/b.star", []byte(`load("a.star", "a")
+b = 1`), 0o644))
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, container.Invoke(func(facade filesystem.Facade) error {
+			return facade.AddDisk("my_disk", disk)
+		}))
+
+		assert.ErrorContains(
+			t,
+			config.NewProvider().(flam.BootableProvider).Boot(container),
+			config.ErrStarlarkLoadCycle.Error())
+	})
+}
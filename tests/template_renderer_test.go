@@ -0,0 +1,237 @@
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/dig"
+
+	flam "github.com/happyhippyhippo/flam"
+	config "github.com/happyhippyhippo/flam-config"
+	mocks "github.com/happyhippyhippo/flam-config/tests/mocks"
+	filesystem "github.com/happyhippyhippo/flam-filesystem"
+	flamTime "github.com/happyhippyhippo/flam-time"
+)
+
+func Test_templateRenderer(t *testing.T) {
+	parsers := flam.Bag{
+		"yaml": flam.Bag{"driver": config.ParserDriverYaml},
+		"json": flam.Bag{"driver": config.ParserDriverJson},
+	}
+
+	hostname, _ := os.Hostname()
+
+	t.Run("should render file sources", func(t *testing.T) {
+		t.Setenv("FLAM_TEMPLATE_VALUE", "env value")
+
+		scenarios := []struct {
+			test     string
+			template any
+			content  string
+			expected any
+			err      error
+			msg      string
+		}{
+			{
+				test:     "template disabled",
+				content:  `field: "{{ .Env.FLAM_TEMPLATE_VALUE }}"`,
+				expected: "{{ .Env.FLAM_TEMPLATE_VALUE }}",
+			},
+			{
+				test:     "environment variable",
+				template: true,
+				content:  `field: "{{ .Env.FLAM_TEMPLATE_VALUE }}"`,
+				expected: "env value",
+			},
+			{
+				test:     "hostname",
+				template: true,
+				content:  `field: "{{ .Hostname }}"`,
+				expected: hostname,
+			},
+			{
+				test:     "aggregated config value",
+				template: true,
+				content:  `field: "{{ .Config.app.name }}"`,
+				expected: "my app",
+			},
+			{
+				test:     "default helper on missing value",
+				template: true,
+				content:  `field: "{{ .Env.FLAM_TEMPLATE_MISSING | default "fallback" }}"`,
+				expected: "fallback",
+			},
+			{
+				test:     "default helper on present value",
+				template: true,
+				content:  `field: "{{ .Env.FLAM_TEMPLATE_VALUE | default "fallback" }}"`,
+				expected: "env value",
+			},
+			{
+				test:     "required helper on present value",
+				template: true,
+				content:  `field: "{{ required "value is required" .Env.FLAM_TEMPLATE_VALUE }}"`,
+				expected: "env value",
+			},
+			{
+				test:     "toJson helper",
+				template: true,
+				content:  `field: {{ .Config.app | toJson }}`,
+				expected: flam.Bag{"name": "my app"},
+			},
+			{
+				test:     "b64dec helper",
+				template: true,
+				content:  `field: "{{ "ZGVjb2RlZA==" | b64dec }}"`,
+				expected: "decoded",
+			},
+			{
+				test:     "required helper on missing value",
+				template: true,
+				content:  `field: "{{ required "value is required" .Env.FLAM_TEMPLATE_MISSING }}"`,
+				err:      config.ErrTemplateRender,
+				msg:      "value is required",
+			},
+			{
+				test:     "template syntax error",
+				template: true,
+				content:  "first: value\nfield: \"{{ .Env.FLAM_TEMPLATE_VALUE \"",
+				err:      config.ErrTemplateRender,
+				msg:      "/config/file.yaml:2",
+			},
+		}
+
+		for _, scenario := range scenarios {
+			t.Run(scenario.test, func(t *testing.T) {
+				source := flam.Bag{
+					"driver": config.SourceDriverFile,
+					"disk":   "my_disk",
+					"path":   "/config/file.yaml",
+				}
+				if scenario.template != nil {
+					source["template"] = scenario.template
+				}
+
+				config.Defaults = flam.Bag{}
+				_ = config.Defaults.Set(config.PathBoot, true)
+				_ = config.Defaults.Set(config.PathParsers, parsers)
+				_ = config.Defaults.Set(config.PathSources, flam.Bag{"my_source": source})
+				_ = config.Defaults.Set("app.name", "my app")
+				defer func() { config.Defaults = flam.Bag{} }()
+
+				disk := afero.NewMemMapFs()
+				require.NoError(t, afero.WriteFile(disk, "/config/file.yaml", []byte(scenario.content), 0o644))
+
+				container := dig.New()
+				require.NoError(t, flamTime.NewProvider().Register(container))
+				require.NoError(t, filesystem.NewProvider().Register(container))
+				require.NoError(t, config.NewProvider().Register(container))
+				require.NoError(t, container.Invoke(func(facade filesystem.Facade) error {
+					return facade.AddDisk("my_disk", disk)
+				}))
+
+				e := config.NewProvider().(flam.BootableProvider).Boot(container)
+				if scenario.err != nil {
+					assert.ErrorIs(t, e, scenario.err)
+					assert.ErrorContains(t, e, scenario.msg)
+
+					var parseErr *config.ParseError
+					assert.True(t, errors.As(e, &parseErr))
+					return
+				}
+
+				require.NoError(t, e)
+				assert.NoError(t, container.Invoke(func(facade config.Facade) {
+					assert.Equal(t, scenario.expected, facade.Get("field"))
+				}))
+			})
+		}
+	})
+
+	t.Run("should render dir source files", func(t *testing.T) {
+		t.Setenv("FLAM_TEMPLATE_VALUE", "env value")
+
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathBoot, true)
+		_ = config.Defaults.Set(config.PathParsers, parsers)
+		_ = config.Defaults.Set(config.PathSources, flam.Bag{
+			"my_source": flam.Bag{
+				"driver":   config.SourceDriverDir,
+				"disk":     "my_disk",
+				"path":     "/config",
+				"template": true,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		disk := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(disk, "/config/a.yaml", []byte(`yaml: "{{ .Env.FLAM_TEMPLATE_VALUE }}"`), 0o644))
+		require.NoError(t, afero.WriteFile(disk, "/config/b.json", []byte(`{"json": "{{ .Env.FLAM_TEMPLATE_VALUE }}"}`), 0o644))
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, container.Invoke(func(facade filesystem.Facade) error {
+			return facade.AddDisk("my_disk", disk)
+		}))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			assert.Equal(t, "env value", facade.Get("yaml"))
+			assert.Equal(t, "env value", facade.Get("json"))
+		}))
+	})
+
+	t.Run("should render rest responses", func(t *testing.T) {
+		t.Setenv("FLAM_TEMPLATE_VALUE", "env value")
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathBoot, true)
+		_ = config.Defaults.Set(config.PathParsers, parsers)
+		_ = config.Defaults.Set(config.PathSources, flam.Bag{
+			"my_source": flam.Bag{
+				"driver":   config.SourceDriverRest,
+				"uri":      "http://uri",
+				"parser":   "json",
+				"template": true,
+				"path":     flam.Bag{"config": "config"},
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+
+		response := &http.Response{
+			Header: http.Header{},
+			Body:   io.NopCloser(bytes.NewReader([]byte(`{"config": {"field": "{{ .Env.FLAM_TEMPLATE_VALUE }}"}}`))),
+		}
+
+		requester := mocks.NewRestRequester(ctrl)
+		requester.EXPECT().Do(gomock.Any()).Return(response, nil).Times(1)
+
+		requestGenerator := mocks.NewRestRequesterGenerator(ctrl)
+		requestGenerator.EXPECT().Create().Return(requester, nil).Times(1)
+		require.NoError(t, container.Decorate(func(generator config.RestRequesterGenerator) config.RestRequesterGenerator {
+			return requestGenerator
+		}))
+
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			assert.Equal(t, "env value", facade.Get("field"))
+		}))
+	})
+}
@@ -0,0 +1,452 @@
+package tests
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/dig"
+
+	flam "github.com/happyhippyhippo/flam"
+	config "github.com/happyhippyhippo/flam-config"
+	mocks "github.com/happyhippyhippo/flam-config/tests/mocks"
+	filesystem "github.com/happyhippyhippo/flam-filesystem"
+	flamTime "github.com/happyhippyhippo/flam-time"
+)
+
+func Test_yamlParser(t *testing.T) {
+	t.Run("should return parsing error of a later document", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverYaml,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(strings.NewReader("field: value\n---\n{"))
+			assert.Nil(t, got)
+			assert.ErrorContains(t, e, "did not find expected node content")
+		}))
+	})
+
+	t.Run("should merge every document in order", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverYaml,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		data := `
+field: first
+nested:
+  a: 1
+  b: 1
+---
+---
+field: second
+nested:
+  b: 2
+  c: 2
+`
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(strings.NewReader(data))
+			require.NoError(t, e)
+
+			assert.Equal(t, flam.Bag{
+				"field":  "second",
+				"nested": flam.Bag{"a": 1, "b": 2, "c": 2},
+			}, got)
+		}))
+	})
+
+	t.Run("should return the documents as a list under the configured key", func(t *testing.T) {
+		scenarios := []struct {
+			test     string
+			key      string
+			data     string
+			expected flam.Bag
+		}{
+			{
+				test: "simple key",
+				key:  "documents",
+				data: "field: first\n---\nfield: second\n",
+				expected: flam.Bag{
+					"documents": []any{
+						flam.Bag{"field": "first"},
+						flam.Bag{"field": "second"},
+					},
+				},
+			},
+			{
+				test: "mixed case dotted key",
+				key:  "App.Documents",
+				data: "field: first\n",
+				expected: flam.Bag{
+					"app": flam.Bag{
+						"documents": []any{
+							flam.Bag{"field": "first"},
+						},
+					},
+				},
+			},
+			{
+				test:     "no documents",
+				key:      "documents",
+				data:     "",
+				expected: flam.Bag{"documents": []any{}},
+			},
+		}
+
+		for _, scenario := range scenarios {
+			t.Run(scenario.test, func(t *testing.T) {
+				config.Defaults = flam.Bag{}
+				_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+					"my_parser": flam.Bag{
+						"driver":        config.ParserDriverYaml,
+						"documents_key": scenario.key,
+					}})
+				defer func() { config.Defaults = flam.Bag{} }()
+
+				container := dig.New()
+				require.NoError(t, flamTime.NewProvider().Register(container))
+				require.NoError(t, filesystem.NewProvider().Register(container))
+				require.NoError(t, config.NewProvider().Register(container))
+				require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+				assert.NoError(t, container.Invoke(func(facade config.Facade) {
+					parser, e := facade.GetParser("my_parser")
+					require.NoError(t, e)
+
+					got, e := parser.Parse(strings.NewReader(scenario.data))
+					require.NoError(t, e)
+					assert.Equal(t, scenario.expected, got)
+				}))
+			})
+		}
+	})
+
+	t.Run("should resolve env and base64 tags", func(t *testing.T) {
+		require.NoError(t, os.Setenv("YAML_TAG_FIELD", "env_value"))
+		defer func() { _ = os.Unsetenv("YAML_TAG_FIELD") }()
+
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverYaml,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		data := `
+env: !env YAML_TAG_FIELD
+default: !env YAML_TAG_MISSING:-fallback
+base64: !base64 c2VjcmV0
+anchored: &anchor !env YAML_TAG_FIELD
+alias: *anchor
+`
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(strings.NewReader(data))
+			require.NoError(t, e)
+
+			assert.Equal(t, flam.Bag{
+				"env":      "env_value",
+				"default":  "fallback",
+				"base64":   "secret",
+				"anchored": "env_value",
+				"alias":    "env_value",
+			}, got)
+		}))
+	})
+
+	t.Run("should return tag resolution errors with the node position", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverYaml,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		scenarios := []struct {
+			data     string
+			expected string
+		}{
+			{data: "field: value\nenv: !env YAML_TAG_MISSING", expected: ":2:6 !env => yaml tag env variable not found"},
+			{data: "base64: !base64 '*'", expected: ":1:9 !base64 => illegal base64 data"},
+			{data: "list: !env [1, 2]", expected: ":1:7 !env => invalid yaml tag node"},
+			{data: "file: !file secret", expected: ":1:7 !file => yaml tag disk not found"},
+			{data: "include: !include other.yaml", expected: ":1:10 !include => yaml tag disk not found"},
+		}
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			for _, scenario := range scenarios {
+				got, e := parser.Parse(strings.NewReader(scenario.data))
+				assert.Nil(t, got, scenario.data)
+				assert.ErrorIs(t, e, config.ErrYamlTagResolution, scenario.data)
+				assert.ErrorContains(t, e, scenario.expected, scenario.data)
+			}
+		}))
+	})
+
+	t.Run("should resolve file and include tags through the source disk", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathBoot, true)
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverYaml,
+			}})
+		_ = config.Defaults.Set(config.PathSources, flam.Bag{
+			"my_source": flam.Bag{
+				"driver": config.SourceDriverFile,
+				"disk":   "my_disk",
+				"parser": "my_parser",
+				"path":   "/config/main.yaml",
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		disk := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(disk, "/config/main.yaml", []byte("password: !file secrets/db\nshared: !include shared.yaml\n"), 0o644))
+		require.NoError(t, afero.WriteFile(disk, "/config/secrets/db", []byte("secret\n"), 0o644))
+		require.NoError(t, afero.WriteFile(disk, "/config/shared.yaml", []byte("field: value\nnested: !include nested/inner.yaml\n"), 0o644))
+		require.NoError(t, afero.WriteFile(disk, "/config/nested/inner.yaml", []byte("inner: !base64 dmFsdWU=\n"), 0o644))
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, container.Invoke(func(facade filesystem.Facade) error {
+			return facade.AddDisk("my_disk", disk)
+		}))
+
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			assert.Equal(t, "secret", facade.Get("password"))
+			assert.Equal(t, "value", facade.Get("shared.field"))
+			assert.Equal(t, "value", facade.Get("shared.nested.inner"))
+		}))
+	})
+
+	t.Run("should return include cycle error", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathBoot, true)
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverYaml,
+			}})
+		_ = config.Defaults.Set(config.PathSources, flam.Bag{
+			"my_source": flam.Bag{
+				"driver": config.SourceDriverFile,
+				"disk":   "my_disk",
+				"parser": "my_parser",
+				"path":   "/config/main.yaml",
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		disk := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(disk, "/config/main.yaml", []byte("other: !include other.yaml\n"), 0o644))
+		require.NoError(t, afero.WriteFile(disk, "/config/other.yaml", []byte("main: !include main.yaml\n"), 0o644))
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, container.Invoke(func(facade filesystem.Facade) error {
+			return facade.AddDisk("my_disk", disk)
+		}))
+
+		e := config.NewProvider().(flam.BootableProvider).Boot(container)
+		assert.ErrorIs(t, e, config.ErrYamlTagResolution)
+		assert.ErrorContains(t, e, "yaml include cycle: /config/main.yaml")
+	})
+
+	t.Run("should use registered custom tag resolvers", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverYaml,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		expectedErr := errors.New("resolver error")
+		resolver := mocks.NewYamlTagResolver(ctrl)
+		resolver.EXPECT().Tag().Return("!upper").Times(1)
+		gomock.InOrder(
+			resolver.EXPECT().Resolve(gomock.Any(), gomock.Any()).Return("VALUE", nil).Times(1),
+			resolver.EXPECT().Resolve(gomock.Any(), gomock.Any()).Return(nil, expectedErr).Times(1),
+		)
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, container.Provide(func() config.YamlTagResolver {
+			return resolver
+		}, dig.Group(config.YamlTagResolverGroup)))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			require.NoError(t, e)
+
+			got, e := parser.Parse(strings.NewReader("field: !upper value"))
+			require.NoError(t, e)
+			assert.Equal(t, flam.Bag{"field": "VALUE"}, got)
+
+			got, e = parser.Parse(strings.NewReader("field: !upper value"))
+			assert.Nil(t, got)
+			assert.ErrorContains(t, e, "resolver error")
+		}))
+	})
+
+	t.Run("should honour the parser options", func(t *testing.T) {
+		scenarios := []struct {
+			test     string
+			options  flam.Bag
+			data     string
+			expected flam.Bag
+			err      error
+			contains string
+		}{
+			{
+				test:     "duplicate keys are rejected by default",
+				options:  flam.Bag{},
+				data:     "field: 1\nfield: 2",
+				contains: "already defined",
+			},
+			{
+				test:     "duplicate keys are allowed when configured",
+				options:  flam.Bag{"disallow_duplicate_keys": false},
+				data:     "base: &base\n  field: 1\nnode:\n  <<: *base\n  other: 1\n  other: 2\nfield: 1\nfield: 2",
+				expected: flam.Bag{"base": flam.Bag{"field": 1}, "node": flam.Bag{"field": 1, "other": 2}, "field": 2},
+			},
+			{
+				test:    "document exceeding the maximum size",
+				options: flam.Bag{"max_size": 5},
+				data:    "field: 123",
+				err:     config.ErrParserDocumentTooLarge,
+			},
+			{
+				test:    "document exceeding the maximum depth",
+				options: flam.Bag{"max_depth": 2},
+				data:    "field: 1\n---\nnode:\n  list:\n    - 1",
+				err:     config.ErrParserMaxDepthExceeded,
+			},
+			{
+				test:     "known fields in strict mode",
+				options:  flam.Bag{"strict": true, "fields": []any{"db"}},
+				data:     "db:\n  host: localhost",
+				expected: flam.Bag{"db": flam.Bag{"host": "localhost"}},
+			},
+			{
+				test:    "unknown fields in strict mode",
+				options: flam.Bag{"strict": true, "fields": []any{"db.host"}},
+				data:    "db:\n  host: localhost\nlog: debug",
+				err:     config.ErrParserUnknownField,
+			},
+		}
+
+		for _, scenario := range scenarios {
+			t.Run(scenario.test, func(t *testing.T) {
+				parserConfig := flam.Bag{"driver": config.ParserDriverYaml}
+				parserConfig.Merge(scenario.options)
+
+				config.Defaults = flam.Bag{}
+				_ = config.Defaults.Set(config.PathParsers, flam.Bag{"my_parser": parserConfig})
+				defer func() { config.Defaults = flam.Bag{} }()
+
+				container := dig.New()
+				require.NoError(t, flamTime.NewProvider().Register(container))
+				require.NoError(t, filesystem.NewProvider().Register(container))
+				require.NoError(t, config.NewProvider().Register(container))
+				require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+				assert.NoError(t, container.Invoke(func(facade config.Facade) {
+					parser, e := facade.GetParser("my_parser")
+					require.NoError(t, e)
+
+					got, e := parser.Parse(strings.NewReader(scenario.data))
+					switch {
+					case scenario.err != nil:
+						assert.Nil(t, got)
+						assert.ErrorIs(t, e, scenario.err)
+					case scenario.contains != "":
+						assert.Nil(t, got)
+						assert.ErrorContains(t, e, scenario.contains)
+					default:
+						require.NoError(t, e)
+						assert.Equal(t, scenario.expected, got)
+					}
+				}))
+			})
+		}
+	})
+
+	t.Run("should return strict mode without fields error", func(t *testing.T) {
+		config.Defaults = flam.Bag{}
+		_ = config.Defaults.Set(config.PathParsers, flam.Bag{
+			"my_parser": flam.Bag{
+				"driver": config.ParserDriverYaml,
+				"strict": true,
+			}})
+		defer func() { config.Defaults = flam.Bag{} }()
+
+		container := dig.New()
+		require.NoError(t, flamTime.NewProvider().Register(container))
+		require.NoError(t, filesystem.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().Register(container))
+		require.NoError(t, config.NewProvider().(flam.BootableProvider).Boot(container))
+
+		assert.NoError(t, container.Invoke(func(facade config.Facade) {
+			parser, e := facade.GetParser("my_parser")
+			assert.Nil(t, parser)
+			assert.ErrorIs(t, e, config.ErrParserStrictWithoutFields)
+		}))
+	})
+}
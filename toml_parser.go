@@ -0,0 +1,35 @@
+package config
+
+import (
+	"io"
+
+	"github.com/BurntSushi/toml"
+
+	flam "github.com/happyhippyhippo/flam"
+)
+
+type tomlParser struct{}
+
+func newTomlParser() Parser {
+	return &tomlParser{}
+}
+
+func (parser tomlParser) Close() error {
+	return nil
+}
+
+func (parser tomlParser) Parse(
+	reader io.Reader,
+) (flam.Bag, error) {
+	b, e := io.ReadAll(reader)
+	if e != nil {
+		return nil, e
+	}
+
+	data := map[string]any{}
+	if e := toml.Unmarshal(b, &data); e != nil {
+		return nil, e
+	}
+
+	return Convert(data).(flam.Bag), nil
+}
@@ -0,0 +1,23 @@
+package config
+
+import (
+	flam "github.com/happyhippyhippo/flam"
+)
+
+type tomlParserCreator struct{}
+
+func newTomlParserCreator() ParserCreator {
+	return &tomlParserCreator{}
+}
+
+func (tomlParserCreator) Accept(
+	config flam.Bag,
+) bool {
+	return config.String("driver") == ParserDriverToml
+}
+
+func (tomlParserCreator) Create(
+	_ flam.Bag,
+) (Parser, error) {
+	return newTomlParser(), nil
+}
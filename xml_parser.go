@@ -0,0 +1,111 @@
+package config
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+
+	flam "github.com/happyhippyhippo/flam"
+)
+
+type xmlParser struct {
+	attributePrefix string
+	textKey         string
+}
+
+type xmlNode struct {
+	name string
+	bag  flam.Bag
+	text strings.Builder
+}
+
+func newXmlParser(
+	attributePrefix string,
+	textKey string,
+) Parser {
+	return &xmlParser{
+		attributePrefix: attributePrefix,
+		textKey:         textKey,
+	}
+}
+
+func (parser xmlParser) Close() error {
+	return nil
+}
+
+func (parser xmlParser) Parse(
+	reader io.Reader,
+) (flam.Bag, error) {
+	decoder := xml.NewDecoder(reader)
+
+	data := flam.Bag{}
+	var stack []*xmlNode
+	for {
+		token, e := decoder.Token()
+		if errors.Is(e, io.EOF) {
+			break
+		}
+		if e != nil {
+			return nil, e
+		}
+
+		switch typed := token.(type) {
+		case xml.StartElement:
+			node := &xmlNode{name: typed.Name.Local, bag: flam.Bag{}}
+			for _, attribute := range typed.Attr {
+				if attribute.Name.Space == "xmlns" || attribute.Name.Local == "xmlns" {
+					continue
+				}
+				node.bag[parser.attributePrefix+attribute.Name.Local] = attribute.Value
+			}
+			stack = append(stack, node)
+		case xml.CharData:
+			if len(stack) != 0 {
+				stack[len(stack)-1].text.Write(typed)
+			}
+		case xml.EndElement:
+			node := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			target := data
+			if len(stack) != 0 {
+				target = stack[len(stack)-1].bag
+			}
+
+			parser.append(target, node.name, parser.value(node))
+		}
+	}
+
+	return Convert(data).(flam.Bag), nil
+}
+
+func (parser xmlParser) value(
+	node *xmlNode,
+) any {
+	text := strings.TrimSpace(node.text.String())
+	if len(node.bag) == 0 {
+		return text
+	}
+
+	if text != "" {
+		node.bag[parser.textKey] = text
+	}
+
+	return node.bag
+}
+
+func (parser xmlParser) append(
+	target flam.Bag,
+	name string,
+	value any,
+) {
+	switch current := target[name].(type) {
+	case nil:
+		target[name] = value
+	case []any:
+		target[name] = append(current, value)
+	default:
+		target[name] = []any{current, value}
+	}
+}
@@ -0,0 +1,25 @@
+package config
+
+import (
+	flam "github.com/happyhippyhippo/flam"
+)
+
+type xmlParserCreator struct{}
+
+func newXmlParserCreator() ParserCreator {
+	return &xmlParserCreator{}
+}
+
+func (xmlParserCreator) Accept(
+	config flam.Bag,
+) bool {
+	return config.String("driver") == ParserDriverXml
+}
+
+func (xmlParserCreator) Create(
+	config flam.Bag,
+) (Parser, error) {
+	return newXmlParser(
+		config.String("attribute_prefix", DefaultXmlAttributePrefix),
+		config.String("text_key", DefaultXmlTextKey)), nil
+}
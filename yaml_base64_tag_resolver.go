@@ -0,0 +1,34 @@
+package config
+
+import (
+	"encoding/base64"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type yamlBase64TagResolver struct{}
+
+func newYamlBase64TagResolver() YamlTagResolver {
+	return &yamlBase64TagResolver{}
+}
+
+func (yamlBase64TagResolver) Tag() string {
+	return YamlTagBase64
+}
+
+func (yamlBase64TagResolver) Resolve(
+	node *yaml.Node,
+	_ YamlTagContext,
+) (any, error) {
+	if node.Kind != yaml.ScalarNode {
+		return nil, newErrYamlTagInvalidNode(node.Tag)
+	}
+
+	decoded, e := base64.StdEncoding.DecodeString(strings.TrimSpace(node.Value))
+	if e != nil {
+		return nil, e
+	}
+
+	return string(decoded), nil
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type yamlEnvTagResolver struct{}
+
+func newYamlEnvTagResolver() YamlTagResolver {
+	return &yamlEnvTagResolver{}
+}
+
+func (yamlEnvTagResolver) Tag() string {
+	return YamlTagEnv
+}
+
+func (yamlEnvTagResolver) Resolve(
+	node *yaml.Node,
+	_ YamlTagContext,
+) (any, error) {
+	if node.Kind != yaml.ScalarNode {
+		return nil, newErrYamlTagInvalidNode(node.Tag)
+	}
+
+	name, def, hasDefault := strings.Cut(node.Value, ":-")
+	if value, ok := os.LookupEnv(name); ok {
+		return value, nil
+	}
+
+	if hasDefault {
+		return def, nil
+	}
+
+	return nil, newErrYamlTagEnvNotFound(name)
+}
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type yamlFileTagResolver struct{}
+
+func newYamlFileTagResolver() YamlTagResolver {
+	return &yamlFileTagResolver{}
+}
+
+func (yamlFileTagResolver) Tag() string {
+	return YamlTagFile
+}
+
+func (yamlFileTagResolver) Resolve(
+	node *yaml.Node,
+	context YamlTagContext,
+) (any, error) {
+	if node.Kind != yaml.ScalarNode {
+		return nil, newErrYamlTagInvalidNode(node.Tag)
+	}
+
+	content, e := context.ReadFile(node.Value)
+	if e != nil {
+		return nil, e
+	}
+
+	return strings.TrimSuffix(strings.TrimSuffix(string(content), "\n"), "\r"), nil
+}
@@ -0,0 +1,26 @@
+package config
+
+import (
+	"gopkg.in/yaml.v3"
+)
+
+type yamlIncludeTagResolver struct{}
+
+func newYamlIncludeTagResolver() YamlTagResolver {
+	return &yamlIncludeTagResolver{}
+}
+
+func (yamlIncludeTagResolver) Tag() string {
+	return YamlTagInclude
+}
+
+func (yamlIncludeTagResolver) Resolve(
+	node *yaml.Node,
+	context YamlTagContext,
+) (any, error) {
+	if node.Kind != yaml.ScalarNode {
+		return nil, newErrYamlTagInvalidNode(node.Tag)
+	}
+
+	return context.Include(node.Value)
+}
@@ -1,35 +1,185 @@
 package config
 
 import (
+	"bytes"
+	"errors"
 	"io"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 
 	flam "github.com/happyhippyhippo/flam"
+	filesystem "github.com/happyhippyhippo/flam-filesystem"
 )
 
-type yamlParser struct{}
+var yamlErrorLineRegexp = regexp.MustCompile(`^yaml: line (\d+):`)
 
-func newYamlParser() Parser {
-	return &yamlParser{}
+type yamlParser struct {
+	documentsKey string
+	resolvers    map[string]YamlTagResolver
+	options      parserOptions
 }
 
-func (parser yamlParser) Close() error {
+func newYamlParser(
+	documentsKey string,
+	resolvers []YamlTagResolver,
+	options parserOptions,
+) Parser {
+	parser := &yamlParser{
+		documentsKey: documentsKey,
+		resolvers:    map[string]YamlTagResolver{},
+		options:      options,
+	}
+
+	for _, resolver := range resolvers {
+		parser.resolvers[resolver.Tag()] = resolver
+	}
+
+	return parser
+}
+
+func (parser *yamlParser) Close() error {
 	return nil
 }
 
-func (parser yamlParser) Parse(
+func (parser *yamlParser) Parse(
+	reader io.Reader,
+) (flam.Bag, error) {
+	return parser.parse(YamlTagContext{parser: parser}, reader)
+}
+
+func (parser *yamlParser) ParseFrom(
+	disk filesystem.Disk,
+	path string,
+	reader io.Reader,
+) (flam.Bag, error) {
+	return parser.parse(YamlTagContext{
+		parser: parser,
+		disk:   disk,
+		path:   path,
+		chain:  []string{path},
+	}, reader)
+}
+
+func (parser *yamlParser) parse(
+	context YamlTagContext,
 	reader io.Reader,
 ) (flam.Bag, error) {
-	b, e := io.ReadAll(reader)
+	b, e := parser.options.read(reader)
 	if e != nil {
 		return nil, e
 	}
 
-	data := map[string]any{}
-	if e := yaml.Unmarshal(b, &data); e != nil {
-		return nil, e
+	decoder := yaml.NewDecoder(bytes.NewReader(b))
+
+	documents := []any{}
+	for {
+		node := yaml.Node{}
+		if e := decoder.Decode(&node); e != nil {
+			if errors.Is(e, io.EOF) {
+				break
+			}
+
+			if match := yamlErrorLineRegexp.FindStringSubmatch(e.Error()); match != nil {
+				line, _ := strconv.Atoi(match[1])
+				return nil, newPositionError(line, 0, e)
+			}
+
+			return nil, e
+		}
+
+		if e := parser.resolve(&node, context); e != nil {
+			return nil, e
+		}
+
+		if !parser.options.disallowDuplicateKeys {
+			parser.dedupe(&node)
+		}
+
+		data := map[string]any{}
+		if e := node.Decode(&data); e != nil {
+			return nil, e
+		}
+
+		document := Convert(data).(flam.Bag)
+		if e := parser.options.check(document); e != nil {
+			return nil, e
+		}
+
+		documents = append(documents, document)
+	}
+
+	if parser.documentsKey != "" {
+		result := flam.Bag{}
+		if e := result.Set(strings.ToLower(parser.documentsKey), documents); e != nil {
+			return nil, e
+		}
+		return result, nil
 	}
 
-	return Convert(data).(flam.Bag), nil
+	result := flam.Bag{}
+	for _, document := range documents {
+		result.Merge(document.(flam.Bag))
+	}
+
+	return result, nil
+}
+
+func (parser *yamlParser) resolve(
+	node *yaml.Node,
+	context YamlTagContext,
+) error {
+	if resolver, ok := parser.resolvers[node.Tag]; ok {
+		value, e := resolver.Resolve(node, context)
+		if e != nil {
+			return newErrYamlTagResolution(context.path, node.Line, node.Column, node.Tag, e)
+		}
+
+		resolved := yaml.Node{}
+		if e := resolved.Encode(value); e != nil {
+			return newErrYamlTagResolution(context.path, node.Line, node.Column, node.Tag, e)
+		}
+
+		resolved.Anchor = node.Anchor
+		*node = resolved
+
+		return nil
+	}
+
+	for _, child := range node.Content {
+		if e := parser.resolve(child, context); e != nil {
+			return e
+		}
+	}
+
+	return nil
+}
+
+func (parser *yamlParser) dedupe(
+	node *yaml.Node,
+) {
+	if node.Kind == yaml.MappingNode {
+		last := map[string]int{}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if key := node.Content[i]; key.Kind == yaml.ScalarNode && key.Value != "<<" {
+				last[key.Value] = i
+			}
+		}
+
+		var content []*yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			if index, ok := last[key.Value]; ok && key.Kind == yaml.ScalarNode && index != i {
+				continue
+			}
+			content = append(content, node.Content[i], node.Content[i+1])
+		}
+		node.Content = content
+	}
+
+	for _, child := range node.Content {
+		parser.dedupe(child)
+	}
 }
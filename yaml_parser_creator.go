@@ -1,13 +1,27 @@
 package config
 
 import (
+	"go.uber.org/dig"
+
 	flam "github.com/happyhippyhippo/flam"
 )
 
-type yamlParserCreator struct{}
+type yamlParserCreator struct {
+	resolvers []YamlTagResolver
+}
+
+type yamlParserCreatorArgs struct {
+	dig.In
 
-func newYamlParserCreator() ParserCreator {
-	return &yamlParserCreator{}
+	Resolvers []YamlTagResolver `group:"flam.config.parsers.yaml.tag.resolver"`
+}
+
+func newYamlParserCreator(
+	args yamlParserCreatorArgs,
+) ParserCreator {
+	return &yamlParserCreator{
+		resolvers: args.Resolvers,
+	}
 }
 
 func (yamlParserCreator) Accept(
@@ -16,8 +30,16 @@ func (yamlParserCreator) Accept(
 	return config.String("driver") == ParserDriverYaml
 }
 
-func (yamlParserCreator) Create(
-	_ flam.Bag,
+func (creator yamlParserCreator) Create(
+	config flam.Bag,
 ) (Parser, error) {
-	return newYamlParser(), nil
+	options, e := newParserOptions(config, true)
+	if e != nil {
+		return nil, e
+	}
+
+	return newYamlParser(
+		config.String("documents_key"),
+		creator.resolvers,
+		options), nil
 }
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"io"
+	"os"
+	"path"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+
+	filesystem "github.com/happyhippyhippo/flam-filesystem"
+)
+
+type YamlTagResolver interface {
+	Tag() string
+	Resolve(node *yaml.Node, context YamlTagContext) (any, error)
+}
+
+type YamlTagContext struct {
+	parser *yamlParser
+	disk   filesystem.Disk
+	path   string
+	chain  []string
+}
+
+func (context YamlTagContext) Disk() filesystem.Disk {
+	return context.disk
+}
+
+func (context YamlTagContext) Path() string {
+	return context.path
+}
+
+func (context YamlTagContext) Resolve(
+	target string,
+) string {
+	if path.IsAbs(target) || context.path == "" {
+		return target
+	}
+
+	return path.Join(path.Dir(context.path), target)
+}
+
+func (context YamlTagContext) ReadFile(
+	target string,
+) ([]byte, error) {
+	if context.disk == nil {
+		return nil, newErrYamlTagDiskNotFound(target)
+	}
+
+	file, e := context.disk.OpenFile(context.Resolve(target), os.O_RDONLY, 0o644)
+	if e != nil {
+		return nil, e
+	}
+	defer func() { _ = file.Close() }()
+
+	return io.ReadAll(file)
+}
+
+func (context YamlTagContext) Include(
+	target string,
+) (any, error) {
+	if context.disk == nil {
+		return nil, newErrYamlTagDiskNotFound(target)
+	}
+
+	resolved := context.Resolve(target)
+	if slices.Contains(context.chain, resolved) {
+		return nil, newErrYamlIncludeCycle(resolved)
+	}
+
+	file, e := context.disk.OpenFile(resolved, os.O_RDONLY, 0o644)
+	if e != nil {
+		return nil, e
+	}
+	defer func() { _ = file.Close() }()
+
+	return context.parser.parse(YamlTagContext{
+		parser: context.parser,
+		disk:   context.disk,
+		path:   resolved,
+		chain:  append(slices.Clone(context.chain), resolved),
+	}, file)
+}